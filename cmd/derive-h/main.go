@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/group"
+)
+
+// main derives the second generator h for the group selected through the ZKP_* environment
+// variables and prints the derivation transcript, so that auditors can reproduce h from g,
+// the group description and the domain-separation string. It also reports whether the h the
+// prover and verifier would use is the derived one, and exits with status 1 when it is not.
+func main() {
+	domain := flag.String("domain", group.GeneratorHDomain, "domain-separation string")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	h, transcript, err := cfg.DeriveH(*domain)
+	if err != nil {
+		log.Fatalf("failed to derive h: %v", err)
+	}
+
+	fmt.Print(transcript)
+	fmt.Printf("h (decimal): %s\n", h)
+
+	if h.Cmp(cfg.H) != 0 {
+		fmt.Printf("configured h %s does NOT match the derivation\n", cfg.H)
+		os.Exit(1)
+	}
+	fmt.Println("configured h matches the derivation")
+}
@@ -11,14 +11,25 @@ import (
 	interactor "practical-case-test/internal/interactor/grpc"
 )
 
-// main initializes the client and performs the following actions:
+// main validates the group parameters, precomputes the tables of the generators, initializes the client and performs the following actions:
 // 1. Generates a random userName and userPassword.
-// 2. Registers the user with the client using the generated userName and userPassword.
+// 2. Registers the user with the client using the generated userName and userPassword, which derives the
+// secret from the password with Argon2id and a fresh salt.
 // 3. Logs in with the registered user credentials.
-// 4. Prints the session ID of the successful login.
-// 5. Sleeps for 60 seconds before ending the program.
+// 4. Prints the session ID of the successful login and its signed token, if the verifier issues them.
+// 5. Logs in again with a single non-interactive (Fiat-Shamir) proof and prints that session ID.
+// 6. Sleeps for 60 seconds before ending the program.
 func main() {
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if err = cfg.Validate(); err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
+	if err = cfg.Precompute(); err != nil {
+		log.Fatalf("Failed to precompute generator tables: %v", err)
+	}
 
 	client, err := interactor.NewClient(
 		cfg.VerifierURL,
@@ -26,6 +37,8 @@ func main() {
 		app.NewRegister(),
 		app.NewCommitment(),
 		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
 	)
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
@@ -48,7 +61,7 @@ func main() {
 		return
 	}
 
-	sessionID, err := client.Login(context.Background(), userName, userPassword)
+	login, err := client.Login(context.Background(), userName, userPassword)
 	if err != nil {
 		slog.Error("login failed", "error", err)
 		slog.Info("sleeping for 30 seconds after error")
@@ -56,7 +69,17 @@ func main() {
 		return
 	}
 
-	slog.Info("successfully logged", "session id", sessionID)
+	// The session token and the signed token are bearer credentials, so only whether they were issued is logged.
+	slog.Info("successfully logged", "user", userName, "signed token", login.GetSignedToken() != "")
+
+	nonInteractive, err := client.LoginNonInteractive(context.Background(), userName, userPassword)
+	if err != nil {
+		slog.Error("non-interactive login failed", "error", err)
+		return
+	}
+
+	slog.Info("successfully logged without interaction", "user", userName,
+		"signed token", nonInteractive.GetSignedToken() != "")
 
 	slog.Info("sleeping for 60 seconds before killing prover")
 
@@ -1,40 +1,140 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
-	"net"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"practical-case-test/config"
 	"practical-case-test/internal/app"
 	igrpc "practical-case-test/internal/interactor/grpc"
 	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
 	"practical-case-test/internal/repository/memory"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 // main is the entry point of the application. It starts a gRPC server and registers
 // the authentication server handlers. It also initializes the necessary dependencies, such as
 // the authentication repository and the interactor. It uses the config loaded from LoadConfig
-// function. The server listens on port 50051 for incoming connections.
+// function and refuses to start if its group parameters fail Config.Validate, then builds the
+// fixed-base tables of the generators with Config.Precompute, and draws a random session key with
+// Config.EnsureSessionKey unless one is configured, as well as a session signing key with
+// Config.EnsureSessionJWTKey if signed session tokens are enabled. Challenges that are not answered
+// within Config.ChallengeTTL and sessions that outlive Config.SessionTTL or Config.SessionIdleTTL
+// are purged by background reapers. The server listens on Config.ListenAddress, a TCP address or a
+// Unix domain socket, see igrpc.Listen, for incoming connections, over TLS if a certificate is
+// configured, see igrpc.ServerCredentials. Besides the Auth service it serves the grpc.health.v1
+// service, kept up to date by igrpc.StartHealthChecker, and server reflection if Config.Reflection
+// is set. On SIGINT or SIGTERM it stops accepting calls, gives the
+// calls in flight Config.ShutdownGracePeriod to finish, see igrpc.Serve, flushes the repository and
+// exits with the status chosen by shutdown.
 func main() {
-	listener, err := net.Listen("tcp", "0.0.0.0:50051")
+	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if err = cfg.Validate(); err != nil {
+		log.Fatalf("refusing to start: %v", err)
+	}
+	if err = cfg.Precompute(); err != nil {
+		log.Fatalf("failed to precompute generator tables: %v", err)
+	}
+	if err = cfg.EnsureSessionKey(); err != nil {
+		log.Fatalf("failed to set up the session key: %v", err)
+	}
+	if err = cfg.EnsureSessionJWTKey(); err != nil {
+		log.Fatalf("failed to set up the session signing key: %v", err)
 	}
 
-	cfg := config.LoadConfig()
+	listener, err := igrpc.Listen(cfg)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
 
-	s := grpc.NewServer()
+	creds, err := igrpc.ServerCredentials(cfg)
+	if err != nil {
+		log.Fatalf("failed to set up transport credentials: %v", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 
 	ar := memory.NewInMemAuthRepository()
+	drain := igrpc.NewDrain(cfg, ar)
+	s := grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(drain.UnaryInterceptor))
+	ar.StartChallengeReaper(ctx, cfg.ChallengeTTL, cfg.ChallengeTTL)
+	ar.StartSessionReaper(ctx, cfg.SessionTTL, cfg.SessionIdleTTL, cfg.SessionIdleTTL)
+	ar.StartProofReaper(ctx, cfg.FiatShamirMaxSkew, cfg.FiatShamirMaxSkew)
 	ru := app.NewRegisterUser(ar)
 	ca := app.NewCreateAuthenticationChallenge(ar)
 	va := app.NewVerifyAuthentication(ar)
+	ln := app.NewLoginNonInteractive(ar)
+	gs := app.NewGetSalt(ar)
+	vs := app.NewValidateSession(ar)
+	rs := app.NewRefreshSession(ar)
+	lo := app.NewLogout(ar)
+	ls := app.NewListSessions(ar)
+	ra := app.NewRevokeAllSessions(ar)
+	pk := app.NewGetPublicKeys()
 
-	interactor.RegisterAuthServer(s, igrpc.NewAuthenticationServer(cfg, ru, ca, va))
+	interactor.RegisterAuthServer(s, igrpc.NewAuthenticationServer(cfg, ru, ca, va, ln, gs, vs, rs, lo, ls, ra, pk))
 
-	if err = s.Serve(listener); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(s, hs)
+	igrpc.StartHealthChecker(ctx, hs, cfg, ar)
+	if cfg.Reflection {
+		reflection.Register(s)
 	}
+
+	slog.Info("verifier listening", "address", igrpc.DialAddress(listener))
+	deadline, err := igrpc.Serve(ctx, s, listener, cfg.ShutdownGracePeriod, drain)
+	stop()
+	os.Exit(shutdown(ar, deadline, err))
+}
+
+// Exit statuses of the verifier: exitOK after a clean shutdown, exitFailure if it could not start or serve,
+// and exitUnclean if it stopped but had to cut off calls in flight or could not flush the repository.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUnclean = 2
+)
+
+// shutdown logs how serving ended with serveErr, the result of igrpc.Serve, flushes ar by deadline, the end of
+// the grace period igrpc.Serve returned, or without a deadline if it is zero, and returns the exit status of
+// the verifier.
+func shutdown(ar repository.AuthRepository, deadline time.Time, serveErr error) int {
+	status := exitOK
+	switch {
+	case errors.Is(serveErr, igrpc.ErrShutdownTimeout):
+		slog.Error("calls cut off at shutdown", "error", serveErr)
+		status = exitUnclean
+	case serveErr != nil:
+		slog.Error("failed to serve", "error", serveErr)
+		status = exitFailure
+	}
+
+	ctx := context.Background()
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+	if err := ar.Flush(ctx); err != nil {
+		slog.Error("failed to flush the repository", "error", err)
+		if status == exitOK {
+			status = exitUnclean
+		}
+	}
+
+	slog.Info("verifier stopped", "status", status)
+	return status
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	igrpc "practical-case-test/internal/interactor/grpc"
+	"practical-case-test/internal/repository/memory"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestShutdown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		deadline time.Time
+		serveErr error
+		want     int
+	}{
+		{
+			name:     "Clean shutdown within the grace period",
+			deadline: time.Now().Add(time.Minute),
+			want:     exitOK,
+		},
+		{
+			name: "Clean shutdown without a grace period",
+			want: exitOK,
+		},
+		{
+			name:     "Calls cut off",
+			deadline: time.Now().Add(-time.Second),
+			serveErr: igrpc.ErrShutdownTimeout,
+			want:     exitUnclean,
+		},
+		{
+			name:     "Flush after the grace period",
+			deadline: time.Now().Add(-time.Second),
+			want:     exitUnclean,
+		},
+		{
+			name:     "Serving failed",
+			deadline: time.Now().Add(time.Minute),
+			serveErr: errors.New("listener closed"),
+			want:     exitFailure,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, shutdown(memory.NewInMemAuthRepository(), tt.deadline, tt.serveErr))
+		})
+	}
+}
+
+func TestShutdown_IdleWithoutGracePeriod(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	deadline, err := igrpc.Serve(ctx, grpc.NewServer(), listener, 0, nil)
+	require.Equal(t, exitOK, shutdown(memory.NewInMemAuthRepository(), deadline, err),
+		"an idle verifier without a grace period should exit cleanly")
+}
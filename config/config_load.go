@@ -1,40 +1,450 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"fmt"
 	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"practical-case-test/internal/group"
 
 	"github.com/spf13/viper"
 )
 
+// Defaults of the timing settings.
+const (
+	// defaultFiatShamirMaxSkew is the default tolerance between the clocks of the prover and the verifier.
+	defaultFiatShamirMaxSkew = 30 * time.Second
+	// defaultShutdownGracePeriod is the default time the verifier gives in-flight calls to finish when it stops.
+	defaultShutdownGracePeriod = 10 * time.Second
+	// defaultHealthCheckInterval is the default time between two checks of the health of the verifier.
+	defaultHealthCheckInterval = 5 * time.Second
+	// defaultChallengeTTL is the default time a prover has to answer an interactive challenge.
+	defaultChallengeTTL = time.Minute
+	// defaultSessionTTL is the default lifetime of a session.
+	defaultSessionTTL = 24 * time.Hour
+	// defaultSessionIdleTTL is the default time a session survives without being refreshed.
+	defaultSessionIdleTTL = 30 * time.Minute
+)
+
+// The default Argon2id cost is the second recommended option of RFC 9106: three passes over 64 MiB
+// with four lanes. The default limits on the cost a user may register with allow ten passes over 1 GiB with
+// sixteen lanes, well above any cost a prover would pick but low enough for every prover to afford.
+const (
+	defaultArgon2Time         = 3
+	defaultArgon2MemoryKiB    = 64 * 1024
+	defaultArgon2Threads      = 4
+	defaultArgon2MaxTime      = 10
+	defaultArgon2MaxMemoryKiB = 1024 * 1024
+	defaultArgon2MaxThreads   = 16
+)
+
+// defaultPrecomputeWindow is the default window width of the fixed-base tables of the generators, which
+// keeps them at a few MiB for the largest preset. maxPrecomputeWindow bounds it, since every extra bit
+// roughly doubles the size of the tables.
+const (
+	defaultPrecomputeWindow = 4
+	maxPrecomputeWindow     = 8
+)
+
+// defaultListenAddress is the default address the verifier listens on, and defaultListenSocketMode the default
+// file mode of its socket when it listens on a Unix domain socket: readable and writable by its owner and group.
+const (
+	defaultListenAddress    = "0.0.0.0:50051"
+	defaultListenSocketMode = "0660"
+)
+
+// Config holds the public parameters of the Chaum-Pedersen protocol and the settings of the verifier and
+// the prover. LoadConfig fills it from the ZKP_ environment variables.
 type Config struct {
-	G           *big.Int
-	H           *big.Int
-	Q           *big.Int
+	// Group is the name of the preset the group parameters were taken from, or GroupCustom.
+	Group string
+	// G and H generate the subgroup of prime order Q of the multiplicative group modulo the prime P: group
+	// arithmetic is done mod P and exponent arithmetic mod Q. With GroupRistretto255, Q is the curve group
+	// order, G and H hold point encodings and P is unused.
+	G *big.Int
+	H *big.Int
+	P *big.Int
+	Q *big.Int
+
+	// ListenAddress is the address the verifier listens on, a TCP host:port or unix:///path of a Unix domain
+	// socket, which is created with ListenSocketMode.
+	ListenAddress    string
+	ListenSocketMode os.FileMode
+	// ShutdownGracePeriod is how long the verifier lets in-flight calls finish once asked to stop.
+	ShutdownGracePeriod time.Duration
+	// HealthCheckInterval is how often the verifier checks its repository to report its health.
+	HealthCheckInterval time.Duration
+	// Reflection registers the gRPC server reflection service.
+	Reflection bool
+	// VerifierURL is the address the prover connects to, in the same forms as ListenAddress.
 	VerifierURL string
+
+	// FiatShamirMaxSkew bounds how far the timestamp of a non-interactive proof may lie from the verifier's
+	// clock.
+	FiatShamirMaxSkew time.Duration
+	// Argon2Time, Argon2MemoryKiB and Argon2Threads are the Argon2id cost parameters the prover derives the
+	// secret of a new user from the password with; the verifier stores them with the user, and logins use the
+	// stored cost instead.
+	Argon2Time      uint32
+	Argon2MemoryKiB uint32
+	Argon2Threads   uint32
+	// Argon2MaxTime, Argon2MaxMemoryKiB and Argon2MaxThreads bound the cost a user may register with, and the
+	// cost a prover agrees to derive a secret with.
+	Argon2MaxTime      uint32
+	Argon2MaxMemoryKiB uint32
+	Argon2MaxThreads   uint32
+	// ChallengeBits limits interactive challenges to that many bits; zero draws them from the full range
+	// [1, Q).
+	ChallengeBits uint
+	// ChallengeTTL is how long an interactive challenge can be answered after it was issued.
+	ChallengeTTL time.Duration
+
+	// SessionTTL is how long a session stays valid after the login that opened it at most, and SessionIdleTTL
+	// how long it stays valid after it was last refreshed.
+	SessionTTL     time.Duration
+	SessionIdleTTL time.Duration
+	// AdminUsers may list and revoke the sessions of any user, see IsAdmin.
+	AdminUsers []string
+	// DeviceLabel is the name the prover gives its device in the sessions it opens, such as "work laptop".
+	DeviceLabel string
+	// SessionKey is the key session tokens are hashed with before the verifier stores them.
+	SessionKey []byte
+	// SessionJWT makes the verifier also hand out session tokens signed with the Ed25519 key SessionJWTKey.
+	SessionJWT    bool
+	SessionJWTKey ed25519.PrivateKey
+
+	// TLSCertFile and TLSKeyFile are the PEM files of the certificate the verifier serves TLS with, or the
+	// prover presents as its client certificate.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSCAFile is the PEM file of the CAs the certificate of the peer is checked against.
+	TLSCAFile string
+	// TLSClientAuth makes the verifier require client certificates signed by the CAs of TLSCAFile.
+	TLSClientAuth bool
+
+	// PrecomputeWindow is the window width of the fixed-base tables Precompute builds for G and H; zero
+	// disables them.
+	PrecomputeWindow uint
+
+	fixed *fixedBases
 }
 
 // LoadConfig loads the configuration settings from environment variables using Viper.
 // It sets default values for the configuration options if the corresponding environment variable is not set.
-// The function returns a pointer to a Config struct that contains the loaded configuration values.
-func LoadConfig() *Config {
+// The group parameters come from the preset named by ZKP_GROUP (modp-2048 by default); when ZKP_GROUP is
+// "custom" they are read from ZKP_G, ZKP_P and ZKP_Q instead, in decimal or 0x-prefixed hexadecimal.
+// The second generator h is derived with DeriveH unless a custom group sets ZKP_H explicitly.
+// The function returns a pointer to a Config struct that contains the loaded configuration values, or an
+// error naming the first variable whose value is invalid.
+func LoadConfig() (*Config, error) {
 	viper.SetEnvPrefix("zkp")
 
+	cfg := &Config{}
+	for _, load := range []func(cfg *Config) error{
+		loadListener, loadShutdown, loadHealth, loadChallenges, loadKDF, loadSessions, loadJWT, loadTLS, loadGroup,
+	} {
+		if err := load(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// loadListener loads the addresses the verifier listens on and the prover connects to.
+func loadListener(cfg *Config) error {
+	_ = viper.BindEnv("listen_address")
+	viper.SetDefault("listen_address", defaultListenAddress)
+
+	_ = viper.BindEnv("listen_socket_mode")
+	viper.SetDefault("listen_socket_mode", defaultListenSocketMode)
+
 	_ = viper.BindEnv("verifier_url")
 	viper.SetDefault("verifier_url", "localhost:50051")
 
+	cfg.ListenAddress = viper.GetString("listen_address")
+	cfg.VerifierURL = viper.GetString("verifier_url")
+
+	var err error
+	cfg.ListenSocketMode, err = getFileMode("listen_socket_mode")
+	return err
+}
+
+// loadShutdown loads the grace period of the verifier and rejects a negative one.
+func loadShutdown(cfg *Config) error {
+	_ = viper.BindEnv("shutdown_grace_period")
+	viper.SetDefault("shutdown_grace_period", defaultShutdownGracePeriod)
+
+	cfg.ShutdownGracePeriod = viper.GetDuration("shutdown_grace_period")
+	if cfg.ShutdownGracePeriod < 0 {
+		return fmt.Errorf("invalid value %s for ZKP_SHUTDOWN_GRACE_PERIOD, want a non-negative duration",
+			cfg.ShutdownGracePeriod)
+	}
+	return nil
+}
+
+// loadHealth loads the settings of the health checking and reflection services of the verifier.
+func loadHealth(cfg *Config) error {
+	_ = viper.BindEnv("health_check_interval")
+	viper.SetDefault("health_check_interval", defaultHealthCheckInterval)
+
+	_ = viper.BindEnv("reflection")
+	viper.SetDefault("reflection", false)
+
+	cfg.HealthCheckInterval = viper.GetDuration("health_check_interval")
+	cfg.Reflection = viper.GetBool("reflection")
+	if cfg.HealthCheckInterval <= 0 {
+		return fmt.Errorf("invalid value %s for ZKP_HEALTH_CHECK_INTERVAL, want a positive duration",
+			cfg.HealthCheckInterval)
+	}
+	return nil
+}
+
+// loadChallenges loads the settings of interactive challenges and non-interactive proofs.
+func loadChallenges(cfg *Config) error {
+	_ = viper.BindEnv("fiat_shamir_max_skew")
+	viper.SetDefault("fiat_shamir_max_skew", defaultFiatShamirMaxSkew)
+
+	_ = viper.BindEnv("challenge_bits")
+	viper.SetDefault("challenge_bits", 0)
+
+	_ = viper.BindEnv("challenge_ttl")
+	viper.SetDefault("challenge_ttl", defaultChallengeTTL)
+
+	cfg.FiatShamirMaxSkew = viper.GetDuration("fiat_shamir_max_skew")
+	cfg.ChallengeBits = viper.GetUint("challenge_bits")
+	cfg.ChallengeTTL = viper.GetDuration("challenge_ttl")
+	if cfg.FiatShamirMaxSkew <= 0 {
+		return fmt.Errorf("invalid value %s for ZKP_FIAT_SHAMIR_MAX_SKEW, want a positive duration",
+			cfg.FiatShamirMaxSkew)
+	}
+	if cfg.ChallengeTTL <= 0 {
+		return fmt.Errorf("invalid value %s for ZKP_CHALLENGE_TTL, want a positive duration", cfg.ChallengeTTL)
+	}
+	return nil
+}
+
+// loadKDF loads the Argon2id cost parameters and their limits, and rejects a cost above its limit.
+func loadKDF(cfg *Config) error {
+	_ = viper.BindEnv("argon2_time")
+	viper.SetDefault("argon2_time", defaultArgon2Time)
+
+	_ = viper.BindEnv("argon2_memory")
+	viper.SetDefault("argon2_memory", defaultArgon2MemoryKiB)
+
+	_ = viper.BindEnv("argon2_threads")
+	viper.SetDefault("argon2_threads", defaultArgon2Threads)
+
+	_ = viper.BindEnv("argon2_max_time")
+	viper.SetDefault("argon2_max_time", defaultArgon2MaxTime)
+
+	_ = viper.BindEnv("argon2_max_memory")
+	viper.SetDefault("argon2_max_memory", defaultArgon2MaxMemoryKiB)
+
+	_ = viper.BindEnv("argon2_max_threads")
+	viper.SetDefault("argon2_max_threads", defaultArgon2MaxThreads)
+
+	cfg.Argon2Time = viper.GetUint32("argon2_time")
+	cfg.Argon2MemoryKiB = viper.GetUint32("argon2_memory")
+	cfg.Argon2Threads = viper.GetUint32("argon2_threads")
+	cfg.Argon2MaxTime = viper.GetUint32("argon2_max_time")
+	cfg.Argon2MaxMemoryKiB = viper.GetUint32("argon2_max_memory")
+	cfg.Argon2MaxThreads = viper.GetUint32("argon2_max_threads")
+
+	for _, limit := range []struct {
+		costKey, maxKey string
+		cost, max       uint32
+	}{
+		{"ARGON2_TIME", "ARGON2_MAX_TIME", cfg.Argon2Time, cfg.Argon2MaxTime},
+		{"ARGON2_MEMORY", "ARGON2_MAX_MEMORY", cfg.Argon2MemoryKiB, cfg.Argon2MaxMemoryKiB},
+		{"ARGON2_THREADS", "ARGON2_MAX_THREADS", cfg.Argon2Threads, cfg.Argon2MaxThreads},
+	} {
+		if limit.max == 0 {
+			return fmt.Errorf("invalid value 0 for ZKP_%s, want a positive limit", limit.maxKey)
+		}
+		if limit.cost > limit.max {
+			return fmt.Errorf("invalid value %d for ZKP_%s, want at most ZKP_%s = %d",
+				limit.cost, limit.costKey, limit.maxKey, limit.max)
+		}
+	}
+	return nil
+}
+
+// loadSessions loads the lifetimes of sessions, the admins, the device label and the key session tokens are
+// hashed with.
+func loadSessions(cfg *Config) error {
+	_ = viper.BindEnv("session_ttl")
+	viper.SetDefault("session_ttl", defaultSessionTTL)
+
+	_ = viper.BindEnv("session_idle_ttl")
+	viper.SetDefault("session_idle_ttl", defaultSessionIdleTTL)
+
+	_ = viper.BindEnv("admin_users")
+
+	_ = viper.BindEnv("device_label")
+
+	_ = viper.BindEnv("session_key")
+
+	cfg.SessionTTL = viper.GetDuration("session_ttl")
+	cfg.SessionIdleTTL = viper.GetDuration("session_idle_ttl")
+	cfg.AdminUsers = getList("admin_users")
+	cfg.DeviceLabel = viper.GetString("device_label")
+	if cfg.SessionTTL <= 0 {
+		return fmt.Errorf("invalid value %s for ZKP_SESSION_TTL, want a positive duration", cfg.SessionTTL)
+	}
+	if cfg.SessionIdleTTL <= 0 {
+		return fmt.Errorf("invalid value %s for ZKP_SESSION_IDLE_TTL, want a positive duration", cfg.SessionIdleTTL)
+	}
+
+	var err error
+	cfg.SessionKey, err = getSessionKey("session_key")
+	return err
+}
+
+// loadJWT loads whether the verifier hands out signed session tokens and the key it signs them with.
+func loadJWT(cfg *Config) error {
+	_ = viper.BindEnv("session_jwt")
+	viper.SetDefault("session_jwt", false)
+
+	_ = viper.BindEnv("session_jwt_key")
+
+	cfg.SessionJWT = viper.GetBool("session_jwt")
+
+	var err error
+	cfg.SessionJWTKey, err = getSessionJWTKey("session_jwt_key")
+	return err
+}
+
+// loadTLS loads the certificate, key and CA files and whether the verifier requires client certificates.
+// Whether they fit together is checked when the credentials are built.
+func loadTLS(cfg *Config) error {
+	_ = viper.BindEnv("tls_cert_file")
+
+	_ = viper.BindEnv("tls_key_file")
+
+	_ = viper.BindEnv("tls_ca_file")
+
+	_ = viper.BindEnv("tls_client_auth")
+	viper.SetDefault("tls_client_auth", false)
+
+	cfg.TLSCertFile = viper.GetString("tls_cert_file")
+	cfg.TLSKeyFile = viper.GetString("tls_key_file")
+	cfg.TLSCAFile = viper.GetString("tls_ca_file")
+	cfg.TLSClientAuth = viper.GetBool("tls_client_auth")
+	return nil
+}
+
+// loadGroup loads the group parameters, from the preset named by ZKP_GROUP or from ZKP_G, ZKP_P, ZKP_Q and
+// ZKP_H for a custom group, derives h unless it was given, and loads the window width of the fixed-base tables.
+func loadGroup(cfg *Config) error {
+	_ = viper.BindEnv("precompute_window")
+	viper.SetDefault("precompute_window", defaultPrecomputeWindow)
+
+	_ = viper.BindEnv("group")
+	viper.SetDefault("group", GroupMODP2048)
+
 	_ = viper.BindEnv("g")
-	viper.SetDefault("g", "2")
+	viper.SetDefault("g", "4")
 
 	_ = viper.BindEnv("h")
-	viper.SetDefault("h", "5")
+
+	_ = viper.BindEnv("p")
+	viper.SetDefault("p", "2039")
 
 	_ = viper.BindEnv("q")
-	viper.SetDefault("q", "100")
+	viper.SetDefault("q", "1019")
+
+	cfg.Group = viper.GetString("group")
+	cfg.PrecomputeWindow = viper.GetUint("precompute_window")
+
+	var err error
+	if cfg.Group != GroupCustom {
+		if cfg.P, cfg.Q, cfg.G, err = presetGroup(cfg.Group); err != nil {
+			return err
+		}
+	} else {
+		for key, dst := range map[string]**big.Int{"g": &cfg.G, "p": &cfg.P, "q": &cfg.Q} {
+			if *dst, err = getBigInt(key); err != nil {
+				return err
+			}
+		}
+		if viper.GetString("h") != "" {
+			cfg.H, err = getBigInt("h")
+			return err
+		}
+	}
 
-	return &Config{
-		G:           big.NewInt(viper.GetInt64("g")),
-		H:           big.NewInt(viper.GetInt64("h")),
-		Q:           big.NewInt(viper.GetInt64("q")),
-		VerifierURL: viper.GetString("verifier_url"),
+	cfg.H, _, err = cfg.DeriveH(group.GeneratorHDomain)
+	return err
+}
+
+// getBigInt parses the value of the given configuration key as an arbitrary-precision integer.
+// The value may be decimal or carry a 0x, 0o or 0b prefix.
+func getBigInt(key string) (*big.Int, error) {
+	raw := viper.GetString(key)
+	v, ok := new(big.Int).SetString(raw, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid value %q for ZKP_%s", raw, strings.ToUpper(key))
+	}
+	return v, nil
+}
+
+// getFileMode parses the value of the given configuration key as octal permission bits, such as 0660.
+func getFileMode(key string) (os.FileMode, error) {
+	raw := viper.GetString(key)
+	mode, err := strconv.ParseUint(raw, 8, 32)
+	if err != nil || os.FileMode(mode) & ^os.ModePerm != 0 {
+		return 0, fmt.Errorf("invalid value %q for ZKP_%s, want octal permission bits", raw, strings.ToUpper(key))
+	}
+	return os.FileMode(mode), nil
+}
+
+// getList splits the value of the given configuration key at commas, dropping surrounding whitespace and
+// empty items. It returns nil for an unset or empty value.
+func getList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(viper.GetString(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// IsAdmin reports whether user is one of AdminUsers. Being listed alone grants no rights: the verifier only
+// lets an admin register and act as one over a connection with a verified client certificate issued to
+// the admin's name.
+func (c *Config) IsAdmin(user string) bool {
+	return user != "" && slices.Contains(c.AdminUsers, user)
+}
+
+// NewGroup returns the group backend described by the configuration: ristretto255 when Group
+// names it and the order-Q subgroup of the integers modulo P otherwise. P and Q must be set
+// for the latter.
+func (c *Config) NewGroup() group.Group {
+	if c.Group == GroupRistretto255 {
+		return group.NewRistretto255()
+	}
+	return group.NewMODP(c.Group, c.P, c.Q, c.G)
+}
+
+// DeriveH derives the second generator h from G, the group description and the given domain-separation
+// string with group.DeriveH, and returns it in the same integer form as H together with the transcript of
+// the derivation. It fails if G is not an element of the configured group, and leaves validating the
+// remaining parameters to Validate.
+func (c *Config) DeriveH(domain string) (*big.Int, group.Transcript, error) {
+	if c.G == nil || c.Q == nil || c.Q.Sign() <= 0 || (c.Group != GroupRistretto255 && (c.P == nil || c.P.Sign() <= 0)) {
+		return nil, group.Transcript{}, fmt.Errorf("%w: g, p and q must be set to derive h", ErrInvalidGroup)
+	}
+	grp := c.NewGroup()
+	g, err := grp.Decode(c.G.Bytes())
+	if err != nil {
+		return nil, group.Transcript{}, fmt.Errorf("%w: g is not a group element", ErrInvalidGroup)
 	}
+	h, transcript := group.DeriveH(grp, g, domain)
+	return new(big.Int).SetBytes(grp.Encode(h)), transcript, nil
 }
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"practical-case-test/internal/group"
+
+	"github.com/stretchr/testify/require"
+)
+
+// presetConfig returns the configuration LoadConfig builds for the named preset.
+func presetConfig(t *testing.T, name string) *Config {
+	t.Helper()
+	p, q, g, err := presetGroup(name)
+	require.NoError(t, err)
+	cfg := &Config{Group: name, P: p, Q: q, G: g}
+	cfg.H, _, err = cfg.DeriveH(group.GeneratorHDomain)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestPresetGroup(t *testing.T) {
+	for _, name := range Groups() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.NoError(t, presetConfig(t, name).Validate())
+		})
+	}
+}
+
+func TestPresetGroup_Unknown(t *testing.T) {
+	_, _, _, err := presetGroup("modp-1024")
+	require.ErrorIs(t, err, ErrUnknownGroup)
+}
+
+func TestConfig_DeriveH(t *testing.T) {
+	for _, name := range []string{GroupMODP2048, GroupRistretto255} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := presetConfig(t, name)
+
+			h, transcript, err := cfg.DeriveH(group.GeneratorHDomain)
+			require.NoError(t, err)
+			require.Equal(t, cfg.H, h, "derivation should be deterministic")
+			require.Equal(t, h, new(big.Int).SetBytes(transcript.H), "transcript should record h")
+			require.Equal(t, group.GeneratorHDomain, transcript.Domain)
+
+			other, _, err := cfg.DeriveH("another domain")
+			require.NoError(t, err)
+			require.NotEqual(t, h, other, "derivation should depend on the domain")
+		})
+	}
+}
+
+func TestConfig_DeriveH_DependsOnG(t *testing.T) {
+	cfg := &Config{Group: GroupCustom, P: big.NewInt(2039), Q: big.NewInt(1019), G: big.NewInt(4)}
+	h1, _, err := cfg.DeriveH(group.GeneratorHDomain)
+	require.NoError(t, err)
+	cfg.G = big.NewInt(9)
+	h2, _, err := cfg.DeriveH(group.GeneratorHDomain)
+	require.NoError(t, err)
+	require.NotEqual(t, h1, h2)
+}
+
+func TestConfig_DeriveH_MissingParameters(t *testing.T) {
+	_, _, err := (&Config{Group: GroupCustom, G: big.NewInt(4)}).DeriveH(group.GeneratorHDomain)
+	require.ErrorIs(t, err, ErrInvalidGroup)
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name: "custom group",
+			env: map[string]string{
+				"ZKP_GROUP": GroupCustom, "ZKP_G": "4", "ZKP_H": "9", "ZKP_P": "0x17", "ZKP_Q": "11",
+				"ZKP_FIAT_SHAMIR_MAX_SKEW": "1m", "ZKP_ARGON2_TIME": "1", "ZKP_ARGON2_MEMORY": "8", "ZKP_ARGON2_THREADS": "1",
+				"ZKP_ARGON2_MAX_TIME": "4", "ZKP_ARGON2_MAX_MEMORY": "65536", "ZKP_ARGON2_MAX_THREADS": "2",
+				"ZKP_CHALLENGE_BITS": "128", "ZKP_PRECOMPUTE_WINDOW": "5",
+				"ZKP_CHALLENGE_TTL": "2m", "ZKP_SESSION_TTL": "1h", "ZKP_SESSION_IDLE_TTL": "10m",
+				"ZKP_ADMIN_USERS": " alice, bob,,", "ZKP_DEVICE_LABEL": "work laptop",
+				"ZKP_SESSION_KEY": strings.Repeat("ab", 32), "ZKP_SESSION_JWT": "true",
+				"ZKP_SESSION_JWT_KEY": strings.Repeat("cd", ed25519.SeedSize), "ZKP_TLS_CLIENT_AUTH": "true",
+				"ZKP_TLS_CERT_FILE": "/certs/verifier.pem", "ZKP_TLS_KEY_FILE": "/certs/verifier-key.pem",
+				"ZKP_TLS_CA_FILE": "/certs/ca.pem", "ZKP_LISTEN_ADDRESS": "unix:///run/zkp/verifier.sock",
+				"ZKP_LISTEN_SOCKET_MODE": "600", "ZKP_VERIFIER_URL": "unix:///run/zkp/verifier.sock",
+				"ZKP_SHUTDOWN_GRACE_PERIOD": "45s", "ZKP_HEALTH_CHECK_INTERVAL": "1s", "ZKP_REFLECTION": "true",
+			},
+			want: &Config{
+				Group:               GroupCustom,
+				G:                   big.NewInt(4),
+				H:                   big.NewInt(9),
+				P:                   big.NewInt(23),
+				Q:                   big.NewInt(11),
+				ListenAddress:       "unix:///run/zkp/verifier.sock",
+				ListenSocketMode:    0o600,
+				ShutdownGracePeriod: 45 * time.Second,
+				HealthCheckInterval: time.Second,
+				Reflection:          true,
+				VerifierURL:         "unix:///run/zkp/verifier.sock",
+				FiatShamirMaxSkew:   time.Minute,
+				Argon2Time:          1,
+				Argon2MemoryKiB:     8,
+				Argon2Threads:       1,
+				Argon2MaxTime:       4,
+				Argon2MaxMemoryKiB:  65536,
+				Argon2MaxThreads:    2,
+				ChallengeBits:       128,
+				ChallengeTTL:        2 * time.Minute,
+				SessionTTL:          time.Hour,
+				SessionIdleTTL:      10 * time.Minute,
+				AdminUsers:          []string{"alice", "bob"},
+				DeviceLabel:         "work laptop",
+				SessionKey:          bytes.Repeat([]byte{0xab}, 32),
+				SessionJWT:          true,
+				SessionJWTKey:       ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0xcd}, ed25519.SeedSize)),
+				TLSCertFile:         "/certs/verifier.pem",
+				TLSKeyFile:          "/certs/verifier-key.pem",
+				TLSCAFile:           "/certs/ca.pem",
+				TLSClientAuth:       true,
+				PrecomputeWindow:    5,
+			},
+		},
+		{
+			name: "custom group with derived h",
+			env:  map[string]string{"ZKP_GROUP": GroupCustom, "ZKP_G": "4", "ZKP_H": "", "ZKP_P": "2039", "ZKP_Q": "1019"},
+			want: func() *Config {
+				cfg := &Config{
+					Group:               GroupCustom,
+					G:                   big.NewInt(4),
+					P:                   big.NewInt(2039),
+					Q:                   big.NewInt(1019),
+					ListenAddress:       defaultListenAddress,
+					ListenSocketMode:    0o660,
+					ShutdownGracePeriod: defaultShutdownGracePeriod,
+					HealthCheckInterval: defaultHealthCheckInterval,
+					VerifierURL:         "localhost:50051",
+					FiatShamirMaxSkew:   defaultFiatShamirMaxSkew,
+					Argon2Time:          defaultArgon2Time,
+					Argon2MemoryKiB:     defaultArgon2MemoryKiB,
+					Argon2Threads:       defaultArgon2Threads,
+					Argon2MaxTime:       defaultArgon2MaxTime,
+					Argon2MaxMemoryKiB:  defaultArgon2MaxMemoryKiB,
+					Argon2MaxThreads:    defaultArgon2MaxThreads,
+					ChallengeTTL:        defaultChallengeTTL,
+					SessionTTL:          defaultSessionTTL,
+					SessionIdleTTL:      defaultSessionIdleTTL,
+					PrecomputeWindow:    defaultPrecomputeWindow,
+				}
+				cfg.H, _, _ = cfg.DeriveH(group.GeneratorHDomain)
+				return cfg
+			}(),
+		},
+		{
+			name:    "unknown group",
+			env:     map[string]string{"ZKP_GROUP": "toy"},
+			wantErr: true,
+		},
+		{
+			name:    "session key not hex",
+			env:     map[string]string{"ZKP_SESSION_KEY": "not hex"},
+			wantErr: true,
+		},
+		{
+			name:    "session key too short",
+			env:     map[string]string{"ZKP_SESSION_KEY": strings.Repeat("ab", 31)},
+			wantErr: true,
+		},
+		{
+			name:    "session signing key of wrong length",
+			env:     map[string]string{"ZKP_SESSION_JWT_KEY": strings.Repeat("cd", 64)},
+			wantErr: true,
+		},
+		{
+			name:    "shutdown grace period negative",
+			env:     map[string]string{"ZKP_SHUTDOWN_GRACE_PERIOD": "-10s"},
+			wantErr: true,
+		},
+		{
+			name:    "health check interval not positive",
+			env:     map[string]string{"ZKP_HEALTH_CHECK_INTERVAL": "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "maximum skew not positive",
+			env:     map[string]string{"ZKP_FIAT_SHAMIR_MAX_SKEW": "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "challenge ttl not positive",
+			env:     map[string]string{"ZKP_CHALLENGE_TTL": "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "challenge ttl negative",
+			env:     map[string]string{"ZKP_CHALLENGE_TTL": "-1m"},
+			wantErr: true,
+		},
+		{
+			name:    "session ttl not positive",
+			env:     map[string]string{"ZKP_SESSION_TTL": "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "session idle ttl not positive",
+			env:     map[string]string{"ZKP_SESSION_IDLE_TTL": "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "session idle ttl negative",
+			env:     map[string]string{"ZKP_SESSION_IDLE_TTL": "-30m"},
+			wantErr: true,
+		},
+		{
+			name:    "argon2 memory limit not positive",
+			env:     map[string]string{"ZKP_ARGON2_MAX_MEMORY": "0"},
+			wantErr: true,
+		},
+		{
+			name:    "argon2 time above its limit",
+			env:     map[string]string{"ZKP_ARGON2_TIME": "5", "ZKP_ARGON2_MAX_TIME": "4"},
+			wantErr: true,
+		},
+		{
+			name:    "socket mode not octal",
+			env:     map[string]string{"ZKP_LISTEN_SOCKET_MODE": "rw-rw----"},
+			wantErr: true,
+		},
+		{
+			name:    "socket mode beyond permission bits",
+			env:     map[string]string{"ZKP_LISTEN_SOCKET_MODE": "4755"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid custom parameter",
+			env:     map[string]string{"ZKP_GROUP": GroupCustom, "ZKP_P": "not-a-number"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			got, err := LoadConfig()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfig_IsAdmin(t *testing.T) {
+	cfg := &Config{AdminUsers: []string{"alice", "bob"}}
+	require.True(t, cfg.IsAdmin("alice"))
+	require.True(t, cfg.IsAdmin("bob"))
+	require.False(t, cfg.IsAdmin("mallory"))
+	require.False(t, cfg.IsAdmin(""))
+	require.False(t, (&Config{}).IsAdmin("alice"), "there should be no admins by default")
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+
+	"practical-case-test/internal/group"
+)
+
+// fixedBases holds the fixed-base tables of the generators together with the parameters they were
+// built from, so that tables left behind by a later change of the configuration are not used.
+type fixedBases struct {
+	group      string
+	g, h, p, q *big.Int
+	fg         *group.FixedBase
+	fh         *group.FixedBase
+}
+
+// Precompute builds fixed-base exponentiation tables for G and H with windows of PrecomputeWindow
+// bits, for FixedBases to hand out. It is meant to be called once at startup, after the group
+// parameters are final; a PrecomputeWindow of zero drops any existing tables instead. The tables
+// take about (2^w - 1)·bits(Q)/w elements per generator.
+// It returns an error wrapping ErrInvalidGroup if G or H is not an element of the configured group.
+func (c *Config) Precompute() error {
+	c.fixed = nil
+	if c.PrecomputeWindow == 0 {
+		return nil
+	}
+	if c.PrecomputeWindow > maxPrecomputeWindow {
+		return fmt.Errorf("%w: precompute window must be at most %d bits", ErrInvalidGroup, maxPrecomputeWindow)
+	}
+	if c.G == nil || c.H == nil || c.Q == nil || c.Q.Sign() <= 0 ||
+		(c.Group != GroupRistretto255 && (c.P == nil || c.P.Sign() <= 0)) {
+		return fmt.Errorf("%w: g, h, p and q must be set to precompute", ErrInvalidGroup)
+	}
+
+	grp := c.NewGroup()
+	g, err := grp.Decode(c.G.Bytes())
+	if err != nil {
+		return fmt.Errorf("%w: g is not a group element", ErrInvalidGroup)
+	}
+	h, err := grp.Decode(c.H.Bytes())
+	if err != nil {
+		return fmt.Errorf("%w: h is not a group element", ErrInvalidGroup)
+	}
+
+	c.fixed = &fixedBases{
+		group: c.Group,
+		g:     new(big.Int).Set(c.G),
+		h:     new(big.Int).Set(c.H),
+		p:     copyInt(c.P),
+		q:     new(big.Int).Set(c.Q),
+		fg:    group.NewFixedBase(grp, g, c.PrecomputeWindow),
+		fh:    group.NewFixedBase(grp, h, c.PrecomputeWindow),
+	}
+	return nil
+}
+
+// FixedBases returns the tables built by Precompute for G and H, or nil for both if Precompute has
+// not been called or any group parameter changed since.
+func (c *Config) FixedBases() (g, h *group.FixedBase) {
+	f := c.fixed
+	if f == nil || f.group != c.Group || !sameInt(f.g, c.G) || !sameInt(f.h, c.H) || !sameInt(f.p, c.P) ||
+		!sameInt(f.q, c.Q) {
+		return nil, nil
+	}
+	return f.fg, f.fh
+}
+
+// copyInt returns a copy of v, or nil if v is nil.
+func copyInt(v *big.Int) *big.Int {
+	if v == nil {
+		return nil
+	}
+	return new(big.Int).Set(v)
+}
+
+// sameInt reports whether a and b are both nil or hold the same value.
+func sameInt(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
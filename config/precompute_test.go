@@ -0,0 +1,86 @@
+package config
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Precompute(t *testing.T) {
+	for _, name := range []string{GroupMODP2048, GroupRistretto255} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := presetConfig(t, name)
+			cfg.PrecomputeWindow = defaultPrecomputeWindow
+
+			fg, fh := cfg.FixedBases()
+			require.Nil(t, fg, "tables should not exist before Precompute")
+			require.Nil(t, fh)
+
+			require.NoError(t, cfg.Precompute())
+			fg, fh = cfg.FixedBases()
+			require.NotNil(t, fg)
+			require.NotNil(t, fh)
+
+			grp := cfg.NewGroup()
+			g, err := grp.Decode(cfg.G.Bytes())
+			require.NoError(t, err)
+			h, err := grp.Decode(cfg.H.Bytes())
+			require.NoError(t, err)
+			require.True(t, fg.Base().Equal(g), "table should be built for g")
+			require.True(t, fh.Base().Equal(h), "table should be built for h")
+			k := big.NewInt(123456789)
+			require.True(t, fg.Exp(k).Equal(grp.Exp(g, k)))
+			require.True(t, fh.Exp(k).Equal(grp.Exp(h, k)))
+
+			copied := *cfg
+			copied.H = new(big.Int).Set(cfg.G)
+			fg, fh = copied.FixedBases()
+			require.Nil(t, fg, "tables should be dropped once h changes")
+			require.Nil(t, fh)
+
+			copied = *cfg
+			copied.Q = new(big.Int).Add(cfg.Q, big.NewInt(2))
+			fg, _ = copied.FixedBases()
+			require.Nil(t, fg, "tables should be dropped once q changes")
+
+			cfg.PrecomputeWindow = 0
+			require.NoError(t, cfg.Precompute())
+			fg, fh = cfg.FixedBases()
+			require.Nil(t, fg, "a zero window should disable the tables")
+			require.Nil(t, fh)
+		})
+	}
+}
+
+func TestConfig_Precompute_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{
+			name: "missing h",
+			cfg:  &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(4), PrecomputeWindow: 4},
+		},
+		{
+			name: "h outside the group",
+			cfg: &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(4), H: big.NewInt(5),
+				PrecomputeWindow: 4},
+		},
+		{
+			name: "window too wide",
+			cfg: &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(4), H: big.NewInt(9),
+				PrecomputeWindow: maxPrecomputeWindow + 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.ErrorIs(t, tt.cfg.Precompute(), ErrInvalidGroup)
+			fg, fh := tt.cfg.FixedBases()
+			require.Nil(t, fg)
+			require.Nil(t, fh)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// minSessionKeyLength is the minimum length in bytes of the key session tokens are hashed with.
+const minSessionKeyLength = 32
+
+// EnsureSessionKey draws a random SessionKey if none is configured. Sessions issued under such a key
+// cannot be looked up by another process, which is no loss as long as sessions are only kept in memory.
+// It returns an error if the system's random source fails.
+func (c *Config) EnsureSessionKey() error {
+	if len(c.SessionKey) > 0 {
+		return nil
+	}
+	key := make([]byte, minSessionKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("failed to draw session key: %w", err)
+	}
+	c.SessionKey = key
+	return nil
+}
+
+// EnsureSessionJWTKey draws a random SessionJWTKey if SessionJWT is set and no key is configured. Tokens
+// signed with such a key can no longer be verified once the verifier restarts.
+// It returns an error if the system's random source fails.
+func (c *Config) EnsureSessionJWTKey() error {
+	if !c.SessionJWT || len(c.SessionJWTKey) > 0 {
+		return nil
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to draw session signing key: %w", err)
+	}
+	c.SessionJWTKey = key
+	return nil
+}
+
+// getSessionKey parses the value of the given configuration key as a hex-encoded session key of at least
+// minSessionKeyLength bytes. It returns nil for an unset or empty value.
+func getSessionKey(key string) ([]byte, error) {
+	raw := viper.GetString(key)
+	if raw == "" {
+		return nil, nil
+	}
+	sessionKey, err := hex.DecodeString(raw)
+	if err != nil || len(sessionKey) < minSessionKeyLength {
+		return nil, fmt.Errorf("invalid value for ZKP_%s: want at least %d hex-encoded bytes", strings.ToUpper(key),
+			minSessionKeyLength)
+	}
+	return sessionKey, nil
+}
+
+// getSessionJWTKey parses the value of the given configuration key as the hex-encoded seed of an Ed25519
+// private key. It returns nil for an unset or empty value.
+func getSessionJWTKey(key string) (ed25519.PrivateKey, error) {
+	raw := viper.GetString(key)
+	if raw == "" {
+		return nil, nil
+	}
+	seed, err := hex.DecodeString(raw)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid value for ZKP_%s: want %d hex-encoded bytes", strings.ToUpper(key),
+			ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_EnsureSessionKey(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{}
+	require.NoError(t, cfg.EnsureSessionKey())
+	require.Len(t, cfg.SessionKey, minSessionKeyLength)
+	require.False(t, bytes.Equal(make([]byte, minSessionKeyLength), cfg.SessionKey), "the key should be random")
+
+	drawn := cfg.SessionKey
+	require.NoError(t, cfg.EnsureSessionKey())
+	require.Equal(t, drawn, cfg.SessionKey, "an existing key should be kept")
+
+	other := &Config{}
+	require.NoError(t, other.EnsureSessionKey())
+	require.NotEqual(t, drawn, other.SessionKey)
+}
+
+func TestConfig_EnsureSessionJWTKey(t *testing.T) {
+	t.Parallel()
+	disabled := &Config{}
+	require.NoError(t, disabled.EnsureSessionJWTKey())
+	require.Nil(t, disabled.SessionJWTKey, "no key should be drawn without SessionJWT")
+
+	cfg := &Config{SessionJWT: true}
+	require.NoError(t, cfg.EnsureSessionJWTKey())
+	require.Len(t, cfg.SessionJWTKey, ed25519.PrivateKeySize)
+
+	drawn := cfg.SessionJWTKey
+	require.NoError(t, cfg.EnsureSessionJWTKey())
+	require.Equal(t, drawn, cfg.SessionJWTKey, "an existing key should be kept")
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidGroup is wrapped by every error returned from Config.Validate.
+var ErrInvalidGroup = errors.New("invalid group parameters")
+
+// primalityRounds is the number of Miller-Rabin rounds run on top of the Baillie-PSW test.
+const primalityRounds = 20
+
+// Validate checks that the configured group parameters describe a sound prime-order group.
+// For integers modulo P it requires P and Q to be prime, Q to divide P-1, G and H to have
+// order Q and H to differ from G. For ristretto255 it requires Q to be the group order and
+// G and H to be distinct non-identity elements. The returned error wraps ErrInvalidGroup and
+// names the check that failed.
+func (c *Config) Validate() error {
+	if c.Q == nil || c.G == nil || c.H == nil {
+		return fmt.Errorf("%w: g, h and q must be set", ErrInvalidGroup)
+	}
+	if c.G.Cmp(c.H) == 0 {
+		return fmt.Errorf("%w: h must differ from g", ErrInvalidGroup)
+	}
+	if c.Group == GroupRistretto255 {
+		return c.validateRistretto255()
+	}
+	return c.validateMODP()
+}
+
+// validateMODP runs the checks for the order-Q subgroup of the integers modulo P.
+func (c *Config) validateMODP() error {
+	one := big.NewInt(1)
+	if c.P == nil || !c.P.ProbablyPrime(primalityRounds) {
+		return fmt.Errorf("%w: p is not prime", ErrInvalidGroup)
+	}
+	if !c.Q.ProbablyPrime(primalityRounds) {
+		return fmt.Errorf("%w: q is not prime", ErrInvalidGroup)
+	}
+	pMinus1 := new(big.Int).Sub(c.P, one)
+	if new(big.Int).Mod(pMinus1, c.Q).Sign() != 0 {
+		return fmt.Errorf("%w: q does not divide p-1", ErrInvalidGroup)
+	}
+	for _, gen := range c.generators() {
+		name, v := gen.name, gen.value
+		if v.Cmp(one) <= 0 || v.Cmp(c.P) >= 0 {
+			return fmt.Errorf("%w: %s must lie in [2, p)", ErrInvalidGroup, name)
+		}
+		if new(big.Int).Exp(v, c.Q, c.P).Cmp(one) != 0 {
+			return fmt.Errorf("%w: %s does not have order q", ErrInvalidGroup, name)
+		}
+	}
+	return nil
+}
+
+// validateRistretto255 runs the checks for the ristretto255 group, whose order is fixed.
+func (c *Config) validateRistretto255() error {
+	grp := c.NewGroup()
+	if c.Q.Cmp(grp.Order()) != 0 {
+		return fmt.Errorf("%w: q is not the ristretto255 group order", ErrInvalidGroup)
+	}
+	identity := grp.Identity()
+	for _, gen := range c.generators() {
+		name, v := gen.name, gen.value
+		e, err := grp.Decode(v.Bytes())
+		if err != nil {
+			return fmt.Errorf("%w: %s is not a ristretto255 element", ErrInvalidGroup, name)
+		}
+		if e.Equal(identity) {
+			return fmt.Errorf("%w: %s must not be the identity", ErrInvalidGroup, name)
+		}
+	}
+	return nil
+}
+
+type namedGenerator struct {
+	name  string
+	value *big.Int
+}
+
+// generators returns g and h, in that order, labelled for error messages.
+func (c *Config) generators() []namedGenerator {
+	return []namedGenerator{{name: "g", value: c.G}, {name: "h", value: c.H}}
+}
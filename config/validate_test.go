@@ -0,0 +1,100 @@
+package config
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	ffdhe := presetConfig(t, GroupFFDHE2048)
+	ristretto := presetConfig(t, GroupRistretto255)
+	rq, rg, rh := ristretto.Q, ristretto.G, ristretto.H
+
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{
+			name: "valid MODP preset",
+			cfg:  ffdhe,
+		},
+		{
+			name: "valid custom group",
+			cfg:  &Config{Group: GroupCustom, P: big.NewInt(2039), Q: big.NewInt(1019), G: big.NewInt(4), H: big.NewInt(9)},
+		},
+		{
+			name: "valid ristretto255",
+			cfg:  &Config{Group: GroupRistretto255, Q: rq, G: rg, H: rh},
+		},
+		{
+			name:    "missing parameters",
+			cfg:     &Config{Group: GroupCustom, P: big.NewInt(23)},
+			wantErr: "g, h and q must be set",
+		},
+		{
+			name:    "composite p",
+			cfg:     &Config{Group: GroupCustom, P: big.NewInt(25), Q: big.NewInt(11), G: big.NewInt(4), H: big.NewInt(9)},
+			wantErr: "p is not prime",
+		},
+		{
+			name:    "composite q",
+			cfg:     &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(100), G: big.NewInt(4), H: big.NewInt(9)},
+			wantErr: "q is not prime",
+		},
+		{
+			name:    "q does not divide p-1",
+			cfg:     &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(7), G: big.NewInt(4), H: big.NewInt(9)},
+			wantErr: "q does not divide p-1",
+		},
+		{
+			name:    "g is the identity",
+			cfg:     &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(1), H: big.NewInt(9)},
+			wantErr: "g must lie in [2, p)",
+		},
+		{
+			name:    "h out of range",
+			cfg:     &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(4), H: big.NewInt(32)},
+			wantErr: "h must lie in [2, p)",
+		},
+		{
+			name:    "g generates the whole group",
+			cfg:     &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(5), H: big.NewInt(9)},
+			wantErr: "g does not have order q",
+		},
+		{
+			name:    "h equals g",
+			cfg:     &Config{Group: GroupCustom, P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(4), H: big.NewInt(4)},
+			wantErr: "h must differ from g",
+		},
+		{
+			name:    "ristretto255 with wrong order",
+			cfg:     &Config{Group: GroupRistretto255, Q: big.NewInt(11), G: rg, H: rh},
+			wantErr: "q is not the ristretto255 group order",
+		},
+		{
+			name:    "ristretto255 with invalid h",
+			cfg:     &Config{Group: GroupRistretto255, Q: rq, G: rg, H: big.NewInt(1)},
+			wantErr: "h is not a ristretto255 element",
+		},
+		{
+			name:    "ristretto255 with identity h",
+			cfg:     &Config{Group: GroupRistretto255, Q: rq, G: rg, H: big.NewInt(0)},
+			wantErr: "h must not be the identity",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidGroup)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -4,81 +4,176 @@ package integration_test
 
 import (
 	"context"
-	"log"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
 	"math/big"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"practical-case-test/internal/repository"
 	"practical-case-test/internal/repository/memory"
+	"practical-case-test/internal/testcert"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
 
 	"practical-case-test/config"
 	"practical-case-test/internal/app"
+	authDomain "practical-case-test/internal/domain/auth"
 	igrpc "practical-case-test/internal/interactor/grpc"
 	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/pkg/sessionjwt"
 )
 
-// runServer starts the server.
+// startServer starts a verifier for the test and returns the address to reach it at.
 //
-// It listens for incoming connections on the specified address and creates a new gRPC server.
+// It listens on ZKP_LISTEN_ADDRESS, or on a free port of localhost if the test does not set it, so that tests do
+// not depend on fixed ports, and creates a new gRPC server with the configuration loaded from the environment.
 // It creates an in-memory authentication repository and initializes the required application executer instances.
-// Finally, it registers the AuthenticationServer with the gRPC server and starts serving incoming requests.
-//
-// Parameters:
-// - address: The address to listen on for incoming connections (e.g., "localhost:50051").
-func runServer(address string) {
-	lis, err := net.Listen("tcp", address)
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+// Finally, it registers the AuthenticationServer with the gRPC server and serves incoming requests until the test
+// ends, when it shuts the server down as the verifier does on a termination signal.
+func startServer(t *testing.T) string {
+	t.Helper()
+	if _, ok := os.LookupEnv("ZKP_LISTEN_ADDRESS"); !ok {
+		t.Setenv("ZKP_LISTEN_ADDRESS", "localhost:0")
 	}
 
-	cfg := config.LoadConfig()
-	s := grpc.NewServer()
-
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, cfg.Precompute())
+	require.NoError(t, cfg.EnsureSessionKey())
+	require.NoError(t, cfg.EnsureSessionJWTKey())
+	creds, err := igrpc.ServerCredentials(cfg)
+	require.NoError(t, err)
+	lis, err := igrpc.Listen(cfg)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
 	ar := memory.NewInMemAuthRepository()
+	drain := igrpc.NewDrain(cfg, ar)
+	s := grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(drain.UnaryInterceptor))
+	ar.StartChallengeReaper(ctx, cfg.ChallengeTTL, cfg.ChallengeTTL)
+	ar.StartSessionReaper(ctx, cfg.SessionTTL, cfg.SessionIdleTTL, cfg.SessionIdleTTL)
+	ar.StartProofReaper(ctx, cfg.FiatShamirMaxSkew, cfg.FiatShamirMaxSkew)
 	ru := app.NewRegisterUser(ar)
 	ca := app.NewCreateAuthenticationChallenge(ar)
 	va := app.NewVerifyAuthentication(ar)
+	ln := app.NewLoginNonInteractive(ar)
+	gs := app.NewGetSalt(ar)
+	vs := app.NewValidateSession(ar)
+	rs := app.NewRefreshSession(ar)
+	lo := app.NewLogout(ar)
+	ls := app.NewListSessions(ar)
+	ra := app.NewRevokeAllSessions(ar)
+	pk := app.NewGetPublicKeys()
 
-	interactor.RegisterAuthServer(s, igrpc.NewAuthenticationServer(cfg, ru, ca, va))
+	interactor.RegisterAuthServer(s, igrpc.NewAuthenticationServer(cfg, ru, ca, va, ln, gs, vs, rs, lo, ls, ra, pk))
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(s, hs)
+	igrpc.StartHealthChecker(ctx, hs, cfg, ar)
+	if cfg.Reflection {
+		reflection.Register(s)
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		_, err := igrpc.Serve(ctx, s, lis, cfg.ShutdownGracePeriod, drain)
+		served <- err
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-served, "the server should shut down cleanly")
+		require.NoError(t, ar.Flush(context.Background()))
+	})
+
+	return igrpc.DialAddress(lis)
+}
 
-	if err = s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+// registeredKDF returns the Argon2id cost parameters a GetSalt response reports the user registered with.
+func registeredKDF(salt *interactor.SaltResponse) authDomain.KDFParams {
+	return authDomain.KDFParams{
+		Time:      salt.GetArgon2Time(),
+		MemoryKiB: salt.GetArgon2MemoryKib(),
+		Threads:   salt.GetArgon2Threads(),
 	}
 }
 
 // Test_FuncTestScenario1 tests the successful register and login scenario.
 //
-// It first starts the server by calling the runServer function in a separate goroutine.
+// It first starts the server by calling the startServer function.
 // Then, it creates a new client using the igrpc.NewClient function.
-// It registers a user using the client's Register method.
-// Finally, it tests the Login method using the registered user's credentials.
+// It registers a user using the client's Register method, and checks that registering the user again fails with
+// memory.ErrUserAlreadyExists.
+// It tests the Login method using the registered user's credentials, also from a second client configured with
+// another Argon2id cost, as on another machine.
+// Finally, it checks that the session it got is reported valid for that user only.
 func Test_FuncTestScenario1(t *testing.T) {
-	go runServer("localhost:50051")
-	time.Sleep(time.Second)
+	address := startServer(t)
 
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
 
 	client, err := igrpc.NewClient(
-		"localhost:50051",
+		address,
 		cfg,
 		app.NewRegister(),
 		app.NewCommitment(),
 		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
 	)
 	require.NoError(t, err)
 
 	userName := "testUser1"
-	userPassword := big.NewInt(123)
+	userPassword := "password-123"
 
 	err = client.Register(context.Background(), userName, userPassword)
 	require.NoError(t, err)
 
-	_, err = client.Login(context.Background(), userName, userPassword)
+	err = client.Register(context.Background(), userName, userPassword)
+	require.ErrorIs(t, err, memory.ErrUserAlreadyExists)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	login, err := client.Login(context.Background(), userName, userPassword)
+	require.NoError(t, err)
+	sessionID := login.GetSessionId()
+
+	otherCfg := *cfg
+	otherCfg.Argon2Time, otherCfg.Argon2MemoryKiB, otherCfg.Argon2Threads = 1, 8*1024, 1
+	otherClient, err := igrpc.NewClient(address, &otherCfg, app.NewRegister(), app.NewCommitment(), app.NewComputeS(),
+		app.NewProveNonInteractive(), app.NewDeriveSecret())
+	require.NoError(t, err)
+	_, err = otherClient.Login(context.Background(), userName, userPassword)
+	require.NoError(t, err, "the registered Argon2id cost should be used, not the one of the client")
+	require.NoError(t, otherClient.Close())
+
+	validation, err := client.ValidateSession(context.Background(), userName, sessionID)
+	require.NoError(t, err)
+	require.True(t, validation.GetValid())
+	require.Equal(t, userName, validation.GetUser())
+	require.Greater(t, validation.GetExpiresAt(), validation.GetLoginTimestamp())
+
+	validation, err = client.ValidateSession(context.Background(), "anotherUser", sessionID)
 	require.NoError(t, err)
+	require.False(t, validation.GetValid(), "the session should not be valid for another user")
 
 	err = client.Close()
 	require.NoError(t, err)
@@ -86,60 +181,67 @@ func Test_FuncTestScenario1(t *testing.T) {
 
 // Test_FuncTestScenario2 tests the scenario of logging in with the wrong password.
 //
-// It starts the server by calling the runServer function in a goroutine.
+// It starts the server by calling the startServer function.
 // Then, it creates a client and registers a user with the correct password.
 // Next, it tries to login with the same user but with a wrong password, expecting an error.
 // Finally, it closes the client connection.
 func Test_FuncTestScenario2(t *testing.T) {
-	go runServer("localhost:50052")
-	time.Sleep(time.Second)
+	address := startServer(t)
 
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
 
 	client, err := igrpc.NewClient(
-		"localhost:50051",
+		address,
 		cfg,
 		app.NewRegister(),
 		app.NewCommitment(),
 		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
 	)
 	require.NoError(t, err)
 
 	userName := "testUser2"
-	correctPassword := big.NewInt(456)
-	wrongPassword := big.NewInt(3)
+	correctPassword := "password-456"
+	wrongPassword := "password-3"
 
 	err = client.Register(context.Background(), userName, correctPassword)
 	require.NoError(t, err)
 
 	_, err = client.Login(context.Background(), userName, wrongPassword)
-	require.Error(t, err)
+	require.ErrorIs(t, err, app.ErrInvalidResponse)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
 
 	err = client.Close()
 	require.NoError(t, err)
 }
 
+// Test_FuncTestScenario3 tests a prover configured with the wrong group order: the generators no longer decode
+// as elements of the group, so neither registration nor login can succeed.
 func Test_FuncTestScenario3(t *testing.T) {
-	go runServer("localhost:50053")
-	time.Sleep(time.Second)
+	address := startServer(t)
 
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
 	cfg.Q = big.NewInt(222)
 
 	client, err := igrpc.NewClient(
-		"localhost:50051",
+		address,
 		cfg,
 		app.NewRegister(),
 		app.NewCommitment(),
 		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
 	)
 	require.NoError(t, err)
 
 	userName := "testUser3"
-	correctPassword := big.NewInt(456)
+	correctPassword := "password-456"
 
 	err = client.Register(context.Background(), userName, correctPassword)
-	require.NoError(t, err)
+	require.Error(t, err)
 
 	_, err = client.Login(context.Background(), userName, correctPassword)
 	require.Error(t, err)
@@ -148,27 +250,31 @@ func Test_FuncTestScenario3(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test_FuncTestScenario4 tests a prover configured with an h outside the order-q subgroup, which is rejected
+// before anything is sent.
 func Test_FuncTestScenario4(t *testing.T) {
-	go runServer("localhost:50054")
-	time.Sleep(time.Second)
+	address := startServer(t)
 
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
 	cfg.H = big.NewInt(222)
 
 	client, err := igrpc.NewClient(
-		"localhost:50051",
+		address,
 		cfg,
 		app.NewRegister(),
 		app.NewCommitment(),
 		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
 	)
 	require.NoError(t, err)
 
 	userName := "testUser4"
-	correctPassword := big.NewInt(456)
+	correctPassword := "password-456"
 
 	err = client.Register(context.Background(), userName, correctPassword)
-	require.NoError(t, err)
+	require.Error(t, err)
 
 	_, err = client.Login(context.Background(), userName, correctPassword)
 	require.Error(t, err)
@@ -177,27 +283,31 @@ func Test_FuncTestScenario4(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test_FuncTestScenario5 tests a prover configured with g = 1: y1 is the identity and the verifier refuses
+// to register it.
 func Test_FuncTestScenario5(t *testing.T) {
-	go runServer("localhost:50055")
-	time.Sleep(time.Second)
+	address := startServer(t)
 
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
 	cfg.G = big.NewInt(1)
 
 	client, err := igrpc.NewClient(
-		"localhost:50051",
+		address,
 		cfg,
 		app.NewRegister(),
 		app.NewCommitment(),
 		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
 	)
 	require.NoError(t, err)
 
 	userName := "testUser5"
-	correctPassword := big.NewInt(456)
+	correctPassword := "password-456"
 
 	err = client.Register(context.Background(), userName, correctPassword)
-	require.NoError(t, err)
+	require.Error(t, err)
 
 	_, err = client.Login(context.Background(), userName, correctPassword)
 	require.Error(t, err)
@@ -205,3 +315,764 @@ func Test_FuncTestScenario5(t *testing.T) {
 	err = client.Close()
 	require.NoError(t, err)
 }
+
+// Test_FuncTestScenario6 tests the non-interactive login scenario.
+//
+// It registers a user, logs in with a single Fiat-Shamir proof and checks that the same
+// login with a wrong password is rejected.
+func Test_FuncTestScenario6(t *testing.T) {
+	address := startServer(t)
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	client, err := igrpc.NewClient(
+		address,
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
+	)
+	require.NoError(t, err)
+
+	userName := "testUser6"
+	correctPassword := "password-789"
+	wrongPassword := "password-3"
+
+	err = client.Register(context.Background(), userName, correctPassword)
+	require.NoError(t, err)
+
+	login, err := client.LoginNonInteractive(context.Background(), userName, correctPassword)
+	require.NoError(t, err)
+	sessionID := login.GetSessionId()
+	require.NotEmpty(t, sessionID)
+
+	_, err = client.LoginNonInteractive(context.Background(), userName, wrongPassword)
+	require.ErrorIs(t, err, app.ErrInvalidProof)
+
+	err = client.Close()
+	require.NoError(t, err)
+}
+
+// Test_FuncTestScenario7 tests that an answered challenge and a non-interactive proof cannot be replayed.
+//
+// It registers a user, then speaks the interactive protocol over a raw gRPC connection: it answers a
+// challenge once, which opens a session, and sends the very same answer again, which must be rejected
+// because the challenge was consumed by the first answer. Likewise, a non-interactive proof sent a second
+// time within its timestamp window must be rejected.
+func Test_FuncTestScenario7(t *testing.T) {
+	address := startServer(t)
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	client, err := igrpc.NewClient(
+		address,
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close())
+	}()
+
+	userName := "testUser7"
+	password := "password-777"
+	require.NoError(t, client.Register(context.Background(), userName, password))
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	auth := interactor.NewAuthClient(conn)
+
+	salt, err := auth.GetSalt(context.Background(), &interactor.SaltRequest{User: userName})
+	require.NoError(t, err)
+	x, err := app.NewDeriveSecret().Exec(cfg, password, salt.GetSalt(), registeredKDF(salt))
+	require.NoError(t, err)
+	commitment, err := app.NewCommitment().Exec(cfg)
+	require.NoError(t, err)
+
+	challenge, err := auth.CreateAuthenticationChallenge(context.Background(), &interactor.AuthenticationChallengeRequest{
+		User: userName, R1Bytes: commitment.R1.Bytes(), R2Bytes: commitment.R2.Bytes(),
+	})
+	require.NoError(t, err)
+	s, err := app.NewComputeS().Exec(cfg, x, commitment.K, challenge, nil)
+	require.NoError(t, err)
+
+	answer := &interactor.AuthenticationAnswerRequest{AuthId: challenge.GetAuthId(), SBytes: s.Bytes()}
+	_, err = auth.VerifyAuthentication(context.Background(), answer)
+	require.NoError(t, err)
+
+	_, err = auth.VerifyAuthentication(context.Background(), answer)
+	require.Equal(t, codes.NotFound, status.Code(err), "a replayed answer should be rejected")
+
+	proof, err := app.NewProveNonInteractive().Exec(cfg, userName, x, time.Now().Unix(), nil)
+	require.NoError(t, err)
+	proofRequest := &interactor.NonInteractiveLoginRequest{
+		User: userName, R1: proof.R1.Bytes(), R2: proof.R2.Bytes(), S: proof.S.Bytes(), Timestamp: proof.Timestamp,
+	}
+	_, err = auth.LoginNonInteractive(context.Background(), proofRequest)
+	require.NoError(t, err)
+
+	_, err = auth.LoginNonInteractive(context.Background(), proofRequest)
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "a replayed proof should be rejected")
+	require.Contains(t, status.Convert(err).Message(), repository.ErrProofReplayed.Error())
+}
+
+// Test_FuncTestScenario8 tests the lifecycle of a session.
+//
+// It registers a user and logs in, refreshes the session, which keeps it valid, then logs out, after which
+// the session is no longer valid and can be neither refreshed nor logged out of again.
+func Test_FuncTestScenario8(t *testing.T) {
+	address := startServer(t)
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	client, err := igrpc.NewClient(
+		address,
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close())
+	}()
+
+	userName := "testUser8"
+	password := "password-888"
+	require.NoError(t, client.Register(context.Background(), userName, password))
+
+	login, err := client.Login(context.Background(), userName, password)
+	require.NoError(t, err)
+	sessionID := login.GetSessionId()
+
+	expiresAt, err := client.RefreshSession(context.Background(), userName, sessionID)
+	require.NoError(t, err)
+	require.Greater(t, expiresAt, time.Now().Unix())
+
+	validation, err := client.ValidateSession(context.Background(), userName, sessionID)
+	require.NoError(t, err)
+	require.True(t, validation.GetValid())
+	require.Equal(t, expiresAt, validation.GetExpiresAt())
+
+	require.NoError(t, client.Logout(context.Background(), userName, sessionID))
+
+	validation, err = client.ValidateSession(context.Background(), userName, sessionID)
+	require.NoError(t, err)
+	require.False(t, validation.GetValid(), "a session should not be valid after logout")
+
+	_, err = client.RefreshSession(context.Background(), userName, sessionID)
+	require.ErrorIs(t, err, repository.ErrSessionNotFound, "a revoked session should not be refreshed")
+	require.Error(t, client.Logout(context.Background(), userName, sessionID), "a session should be revoked only once")
+}
+
+// Test_FuncTestScenario9 tests listing and revoking the sessions of a user.
+//
+// It logs the same user in three times, lists the sessions together with the client they were opened from, revokes all but the current one, and checks that
+// only the current session is left. Another user may neither list nor revoke those sessions, and the IDs in a listing cannot
+// be used in place of the session tokens. The name of an admin cannot be registered without a client certificate.
+func Test_FuncTestScenario9(t *testing.T) {
+	t.Setenv("ZKP_ADMIN_USERS", "admin9")
+	address := startServer(t)
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	cfg.DeviceLabel = "integration laptop"
+
+	client, err := igrpc.NewClient(
+		address,
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close())
+	}()
+
+	userName := "testUser9"
+	password := "password-999"
+	require.NoError(t, client.Register(context.Background(), userName, password))
+	err = client.Register(context.Background(), "admin9", password)
+	require.ErrorIs(t, err, app.ErrAdminCertificateRequired, "the name of an admin should not be free to register")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	sessionIDs := make([]string, 3)
+	for i := range sessionIDs {
+		var login *interactor.AuthenticationAnswerResponse
+		login, err = client.Login(context.Background(), userName, password)
+		require.NoError(t, err)
+		sessionIDs[i] = login.GetSessionId()
+		require.Len(t, sessionIDs[i], 43, "a session token should encode 32 random bytes")
+	}
+	current := sessionIDs[len(sessionIDs)-1]
+
+	sessions, err := client.ListSessions(context.Background(), userName, current, "")
+	require.NoError(t, err)
+	require.Len(t, sessions, len(sessionIDs))
+	currentSessions := 0
+	for _, session := range sessions {
+		require.NotContains(t, sessionIDs, session.GetSessionId(), "a listing should not reveal session tokens")
+		if session.GetCurrent() {
+			currentSessions++
+		}
+		require.True(t, strings.HasPrefix(session.GetPeerAddress(), "127.0.0.1:"), "peer %q", session.GetPeerAddress())
+		require.Contains(t, session.GetUserAgent(), "zkp-prover")
+		require.Equal(t, cfg.DeviceLabel, session.GetDeviceLabel())
+	}
+	require.Equal(t, 1, currentSessions)
+
+	otherUser := "otherUser9"
+	require.NoError(t, client.Register(context.Background(), otherUser, password))
+	otherLogin, err := client.Login(context.Background(), otherUser, password)
+	require.NoError(t, err)
+	otherSession := otherLogin.GetSessionId()
+	_, err = client.ListSessions(context.Background(), otherUser, otherSession, userName)
+	require.ErrorIs(t, err, app.ErrPermissionDenied, "a user who is not an admin should not list the sessions of another user")
+	_, err = client.RevokeAllSessions(context.Background(), otherUser, otherSession, userName, false)
+	require.ErrorIs(t, err, app.ErrPermissionDenied, "a user who is not an admin should not revoke the sessions of another user")
+
+	revoked, err := client.RevokeAllSessions(context.Background(), userName, current, "", true)
+	require.NoError(t, err)
+	require.Equal(t, len(sessionIDs)-1, revoked)
+
+	sessions, err = client.ListSessions(context.Background(), userName, current, "")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.True(t, sessions[0].GetCurrent())
+
+	_, err = client.ValidateSession(context.Background(), userName, sessions[0].GetSessionId())
+	require.ErrorIs(t, err, app.ErrInvalidSessionID, "a listed session ID should not be accepted as a session token")
+
+	validation, err := client.ValidateSession(context.Background(), userName, sessionIDs[0])
+	require.NoError(t, err)
+	require.False(t, validation.GetValid(), "a revoked session should not be valid")
+}
+
+// Test_FuncTestScenario10 tests signed session tokens.
+//
+// It starts a verifier that issues signed tokens, logs in both interactively and non-interactively, fetches the public keys
+// of the verifier and checks that both tokens verify offline and name the user and the listed session. A tampered token
+// and a token checked against another key set are rejected.
+func Test_FuncTestScenario10(t *testing.T) {
+	t.Setenv("ZKP_SESSION_JWT", "true")
+	address := startServer(t)
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	client, err := igrpc.NewClient(
+		address,
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close())
+	}()
+
+	userName := "testUser10"
+	password := "password-1010"
+	require.NoError(t, client.Register(context.Background(), userName, password))
+
+	login, err := client.Login(context.Background(), userName, password)
+	require.NoError(t, err)
+	nonInteractive, err := client.LoginNonInteractive(context.Background(), userName, password)
+	require.NoError(t, err)
+
+	keys, err := client.PublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys.Keys, 1)
+
+	sessions, err := client.ListSessions(context.Background(), userName, login.GetSessionId(), "")
+	require.NoError(t, err)
+	listed := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		listed = append(listed, session.GetSessionId())
+	}
+
+	for _, signed := range []string{login.GetSignedToken(), nonInteractive.GetSignedToken()} {
+		claims, verifyErr := sessionjwt.Verify(signed, keys, time.Now())
+		require.NoError(t, verifyErr)
+		require.Equal(t, userName, claims.Subject)
+		require.Contains(t, listed, claims.SessionID)
+		require.Greater(t, claims.ExpiresAt, time.Now().Unix())
+	}
+	require.NotEqual(t, login.GetSignedToken(), nonInteractive.GetSignedToken())
+
+	parts := strings.Split(login.GetSignedToken(), ".")
+	_, err = sessionjwt.Verify(parts[0]+"."+strings.Split(nonInteractive.GetSignedToken(), ".")[1]+"."+parts[2], keys, time.Now())
+	require.ErrorIs(t, err, sessionjwt.ErrInvalidSignature)
+
+	otherPublic, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherKeys, err := sessionjwt.NewKeySet(otherPublic)
+	require.NoError(t, err)
+	_, err = sessionjwt.Verify(login.GetSignedToken(), otherKeys, time.Now())
+	require.ErrorIs(t, err, sessionjwt.ErrUnknownKey)
+}
+
+// Test_FuncTestScenario11 tests mutual TLS between the prover and the verifier.
+//
+// It generates a CA with a server and a client certificate, starts a verifier that serves TLS and requires client
+// certificates, and checks that a prover presenting its certificate can register and log in, while a prover without a
+// certificate, one trusting another CA and one connecting without TLS are refused. The client certificate also makes
+// its holder an admin who may list the sessions of other users, but only under the name it was issued to.
+func Test_FuncTestScenario11(t *testing.T) {
+	files, err := testcert.Write(t.TempDir())
+	require.NoError(t, err)
+	otherFiles, err := testcert.Write(t.TempDir())
+	require.NoError(t, err)
+
+	t.Setenv("ZKP_TLS_CERT_FILE", files.ServerCertFile)
+	t.Setenv("ZKP_TLS_KEY_FILE", files.ServerKeyFile)
+	t.Setenv("ZKP_TLS_CA_FILE", files.CAFile)
+	t.Setenv("ZKP_TLS_CLIENT_AUTH", "true")
+	t.Setenv("ZKP_ADMIN_USERS", testcert.ClientName+",otherAdmin11")
+	address := startServer(t)
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	cfg.TLSCertFile, cfg.TLSKeyFile = files.ClientCertFile, files.ClientKeyFile
+
+	newClient := func(clientCfg *config.Config) *igrpc.AuthenticationClient {
+		client, clientErr := igrpc.NewClient(
+			address,
+			clientCfg,
+			app.NewRegister(),
+			app.NewCommitment(),
+			app.NewComputeS(),
+			app.NewProveNonInteractive(),
+			app.NewDeriveSecret(),
+		)
+		require.NoError(t, clientErr)
+		t.Cleanup(func() {
+			require.NoError(t, client.Close())
+		})
+		return client
+	}
+
+	userName := "testUser11"
+	password := "password-1111"
+	client := newClient(cfg)
+	require.NoError(t, client.Register(context.Background(), userName, password))
+	login, err := client.Login(context.Background(), userName, password)
+	require.NoError(t, err)
+	require.NotEmpty(t, login.GetSessionId())
+	nonInteractive, err := client.LoginNonInteractive(context.Background(), userName, password)
+	require.NoError(t, err)
+	require.NotEmpty(t, nonInteractive.GetSessionId())
+
+	require.ErrorIs(t, client.Register(context.Background(), "otherAdmin11", password), app.ErrAdminCertificateRequired,
+		"an admin should not register under a name its certificate was not issued to")
+	require.NoError(t, client.Register(context.Background(), testcert.ClientName, password))
+	adminLogin, err := client.Login(context.Background(), testcert.ClientName, password)
+	require.NoError(t, err)
+	sessions, err := client.ListSessions(context.Background(), testcert.ClientName, adminLogin.GetSessionId(), userName)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2, "an admin should list the sessions of another user")
+
+	withoutCertificate := *cfg
+	withoutCertificate.TLSCertFile, withoutCertificate.TLSKeyFile = "", ""
+	otherCA := *cfg
+	otherCA.TLSCAFile = otherFiles.CAFile
+	withoutTLS := *cfg
+	withoutTLS.TLSCertFile, withoutTLS.TLSKeyFile, withoutTLS.TLSCAFile = "", "", ""
+
+	for name, refused := range map[string]*config.Config{
+		"without a client certificate": &withoutCertificate,
+		"trusting another CA":          &otherCA,
+		"without TLS":                  &withoutTLS,
+	} {
+		_, err = newClient(refused).PublicKeys(context.Background())
+		require.Error(t, err, "a prover %s should be refused", name)
+		require.Equal(t, codes.Unavailable, status.Code(err), "a prover %s should be refused", name)
+	}
+}
+
+// Test_FuncTestScenario12 tests that proofs are bound to the TLS connection they are produced for.
+//
+// It starts a verifier serving TLS and opens two connections to it, as a relaying attacker would hold one to the
+// verifier while its victim proves over another. It checks that a non-interactive proof and a response to an
+// interactive challenge bound to one connection are rejected on the other, and accepted on their own.
+func Test_FuncTestScenario12(t *testing.T) {
+	files, err := testcert.Write(t.TempDir())
+	require.NoError(t, err)
+
+	t.Setenv("ZKP_TLS_CERT_FILE", files.ServerCertFile)
+	t.Setenv("ZKP_TLS_KEY_FILE", files.ServerKeyFile)
+	address := startServer(t)
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	cfg.TLSCAFile = files.CAFile
+
+	client, err := igrpc.NewClient(
+		address,
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	userName := "testUser12"
+	password := "password-1212"
+	require.NoError(t, client.Register(context.Background(), userName, password))
+
+	caPEM, err := os.ReadFile(files.CAFile)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(caPEM))
+	connect := func() interactor.AuthClient {
+		conn, connErr := grpc.NewClient(address, grpc.WithTransportCredentials(
+			credentials.NewTLS(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS13})))
+		require.NoError(t, connErr)
+		t.Cleanup(func() {
+			require.NoError(t, conn.Close())
+		})
+		return interactor.NewAuthClient(conn)
+	}
+	exporter := func(p *peer.Peer) []byte {
+		info, ok := p.AuthInfo.(credentials.TLSInfo)
+		require.True(t, ok, "the connection should use TLS")
+		binding, exportErr := info.State.ExportKeyingMaterial("EXPORTER-Channel-Binding", nil, 32)
+		require.NoError(t, exportErr)
+		return binding
+	}
+	victim, attacker := connect(), connect()
+
+	// The victim proves non-interactively for its own connection; the attacker relays the proof on its own.
+	var victimPeer, attackerPeer peer.Peer
+	salt, err := victim.GetSalt(context.Background(), &interactor.SaltRequest{User: userName}, grpc.Peer(&victimPeer))
+	require.NoError(t, err)
+	_, err = attacker.GetSalt(context.Background(), &interactor.SaltRequest{User: userName}, grpc.Peer(&attackerPeer))
+	require.NoError(t, err)
+	require.NotEqual(t, exporter(&victimPeer), exporter(&attackerPeer))
+
+	x, err := app.NewDeriveSecret().Exec(cfg, password, salt.GetSalt(), registeredKDF(salt))
+	require.NoError(t, err)
+	proof, err := app.NewProveNonInteractive().Exec(cfg, userName, x, time.Now().Unix(), exporter(&victimPeer))
+	require.NoError(t, err)
+	proofRequest := &interactor.NonInteractiveLoginRequest{
+		User: userName, R1: proof.R1.Bytes(), R2: proof.R2.Bytes(), S: proof.S.Bytes(), Timestamp: proof.Timestamp,
+	}
+	_, err = attacker.LoginNonInteractive(context.Background(), proofRequest)
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "a relayed proof should be rejected")
+	_, err = victim.LoginNonInteractive(context.Background(), proofRequest)
+	require.NoError(t, err)
+
+	// The attacker relays the commitment of the victim and the challenge it gets back, and the victim answers it
+	// for its own connection.
+	commitment, err := app.NewCommitment().Exec(cfg)
+	require.NoError(t, err)
+	challengeRequest := &interactor.AuthenticationChallengeRequest{
+		User: userName, R1Bytes: commitment.R1.Bytes(), R2Bytes: commitment.R2.Bytes(),
+	}
+	challenge, err := attacker.CreateAuthenticationChallenge(context.Background(), challengeRequest)
+	require.NoError(t, err)
+	s, err := app.NewComputeS().Exec(cfg, x, commitment.K, challenge, exporter(&victimPeer))
+	require.NoError(t, err)
+	_, err = attacker.VerifyAuthentication(context.Background(),
+		&interactor.AuthenticationAnswerRequest{AuthId: challenge.GetAuthId(), SBytes: s.Bytes()})
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "a relayed response should be rejected")
+
+	challenge, err = victim.CreateAuthenticationChallenge(context.Background(), challengeRequest)
+	require.NoError(t, err)
+	s, err = app.NewComputeS().Exec(cfg, x, commitment.K, challenge, exporter(&victimPeer))
+	require.NoError(t, err)
+	_, err = victim.VerifyAuthentication(context.Background(),
+		&interactor.AuthenticationAnswerRequest{AuthId: challenge.GetAuthId(), SBytes: s.Bytes()})
+	require.NoError(t, err)
+}
+
+// Test_FuncTestScenario13 tests a verifier listening on a Unix domain socket, as a local sidecar would.
+//
+// It starts a verifier on a socket in a temporary directory with ZKP_LISTEN_SOCKET_MODE set, checks the permissions
+// of the socket, and registers and logs in a user through a prover whose VerifierURL is the unix:// address of the
+// socket.
+func Test_FuncTestScenario13(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "verifier.sock")
+	t.Setenv("ZKP_LISTEN_ADDRESS", "unix://"+socket)
+	t.Setenv("ZKP_LISTEN_SOCKET_MODE", "0600")
+	t.Setenv("ZKP_VERIFIER_URL", "unix://"+socket)
+	address := startServer(t)
+	require.Equal(t, "unix://"+socket, address)
+
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	require.Equal(t, os.ModeSocket, info.Mode().Type())
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	client, err := igrpc.NewClient(
+		cfg.VerifierURL,
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	userName := "testUser13"
+	password := "password-1313"
+	require.NoError(t, client.Register(context.Background(), userName, password))
+	login, err := client.Login(context.Background(), userName, password)
+	require.NoError(t, err)
+	validation, err := client.ValidateSession(context.Background(), userName, login.GetSessionId())
+	require.NoError(t, err)
+	require.True(t, validation.GetValid())
+}
+
+// Test_FuncTestScenario14 tests the health checking and server reflection services of the verifier.
+//
+// It starts a verifier without reflection and checks that it reports itself and its Auth service as serving
+// through grpc.health.v1, that an unknown service is not found, and that reflection is not available. It then
+// starts a verifier with ZKP_REFLECTION set and checks that reflection lists the Auth and health services.
+func Test_FuncTestScenario14(t *testing.T) {
+	connect := func(address string) *grpc.ClientConn {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, conn.Close())
+		})
+		return conn
+	}
+	listServices := func(conn *grpc.ClientConn) ([]string, error) {
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		err = stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		})
+		if err != nil {
+			return nil, err
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, service := range res.GetListServicesResponse().GetService() {
+			names = append(names, service.GetName())
+		}
+		return names, stream.CloseSend()
+	}
+
+	conn := connect(startServer(t))
+	healthClient := healthpb.NewHealthClient(conn)
+	for _, service := range []string{"", interactor.Auth_ServiceDesc.ServiceName} {
+		res, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus(), "service %q should be serving", service)
+	}
+	_, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown.Service"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = listServices(conn)
+	require.Equal(t, codes.Unimplemented, status.Code(err), "reflection should be off by default")
+
+	t.Setenv("ZKP_REFLECTION", "true")
+	services, err := listServices(connect(startServer(t)))
+	require.NoError(t, err)
+	require.Contains(t, services, interactor.Auth_ServiceDesc.ServiceName)
+	require.Contains(t, services, healthpb.Health_ServiceDesc.ServiceName)
+}
+
+// Test_FuncTestScenario15 tests that GetSalt does not tell registered users from unknown ones.
+//
+// It registers a user, then asks for the salt of a user that is not registered: the verifier must answer
+// like it does for the registered user, with a salt of the same length and the configured Argon2id cost,
+// and must give the same answer when asked again.
+func Test_FuncTestScenario15(t *testing.T) {
+	address := startServer(t)
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	client, err := igrpc.NewClient(
+		address,
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		app.NewDeriveSecret(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+	require.NoError(t, client.Register(context.Background(), "testUser15", "password-1515"))
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	auth := interactor.NewAuthClient(conn)
+
+	registered, err := auth.GetSalt(context.Background(), &interactor.SaltRequest{User: "testUser15"})
+	require.NoError(t, err)
+	unknown, err := auth.GetSalt(context.Background(), &interactor.SaltRequest{User: "nobody15"})
+	require.NoError(t, err, "an unknown user should not be reported")
+	require.Len(t, unknown.GetSalt(), len(registered.GetSalt()))
+	require.Equal(t, registeredKDF(registered), registeredKDF(unknown))
+
+	again, err := auth.GetSalt(context.Background(), &interactor.SaltRequest{User: "nobody15"})
+	require.NoError(t, err)
+	require.Equal(t, unknown.GetSalt(), again.GetSalt(), "the salt of an unknown user should not change")
+}
+
+// droppingProxy forwards TCP connections to a verifier and can drop all of them at once, as a flaky network
+// would.
+type droppingProxy struct {
+	listener net.Listener
+	target   string
+	accepted atomic.Int32
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+// startDroppingProxy starts a droppingProxy for the verifier at target on a free port of localhost, which it
+// serves until the test ends.
+func startDroppingProxy(t *testing.T, target string) *droppingProxy {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	proxy := &droppingProxy{listener: listener, target: target}
+	go proxy.serve()
+	t.Cleanup(func() {
+		require.NoError(t, listener.Close())
+		proxy.drop()
+	})
+	return proxy
+}
+
+func (p *droppingProxy) serve() {
+	for {
+		client, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		server, err := net.Dial("tcp", p.target)
+		if err != nil {
+			_ = client.Close()
+			continue
+		}
+		p.accepted.Add(1)
+		p.mu.Lock()
+		p.conns = append(p.conns, client, server)
+		p.mu.Unlock()
+		go func() {
+			_, _ = io.Copy(server, client)
+			_ = server.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(client, server)
+			_ = client.Close()
+		}()
+	}
+}
+
+// drop closes every connection the proxy forwards.
+func (p *droppingProxy) drop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range p.conns {
+		_ = conn.Close()
+	}
+	p.conns = nil
+}
+
+// droppingDeriveSecret derives secrets like app.DeriveSecret, but first drops the connections of proxy when armed,
+// that is between fetching the salt and sending the proof of a login.
+type droppingDeriveSecret struct {
+	app.DeriveSecretExecuter
+	proxy *droppingProxy
+	armed atomic.Bool
+}
+
+func (d *droppingDeriveSecret) Exec(cfg *config.Config, password string, salt []byte,
+	params authDomain.KDFParams) (*big.Int, error) {
+	if d.armed.Swap(false) {
+		d.proxy.drop()
+	}
+	return d.DeriveSecretExecuter.Exec(cfg, password, salt, params)
+}
+
+// Test_FuncTestScenario16 tests a non-interactive login during which the prover reconnects to the verifier.
+//
+// It connects a prover to a verifier serving TLS through a proxy, and drops the connection after the prover fetched
+// the salt, so that the proof bound to that connection reaches the verifier over a new one. The login must still
+// succeed, with a proof bound to the new connection.
+func Test_FuncTestScenario16(t *testing.T) {
+	files, err := testcert.Write(t.TempDir())
+	require.NoError(t, err)
+
+	t.Setenv("ZKP_TLS_CERT_FILE", files.ServerCertFile)
+	t.Setenv("ZKP_TLS_KEY_FILE", files.ServerKeyFile)
+	proxy := startDroppingProxy(t, startServer(t))
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	cfg.TLSCAFile = files.CAFile
+
+	ds := &droppingDeriveSecret{DeriveSecretExecuter: app.NewDeriveSecret(), proxy: proxy}
+	client, err := igrpc.NewClient(
+		proxy.listener.Addr().String(),
+		cfg,
+		app.NewRegister(),
+		app.NewCommitment(),
+		app.NewComputeS(),
+		app.NewProveNonInteractive(),
+		ds,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	userName := "testUser16"
+	password := "password-1616"
+	require.NoError(t, client.Register(context.Background(), userName, password))
+
+	ds.armed.Store(true)
+	login, err := client.LoginNonInteractive(context.Background(), userName, password)
+	require.NoError(t, err, "a reconnect between the salt and the proof should not fail the login")
+	require.NotEmpty(t, login.GetSessionId())
+	require.False(t, ds.armed.Load(), "the connection should have been dropped during the login")
+	require.Equal(t, int32(2), proxy.accepted.Load(), "the prover should have reconnected once")
+}
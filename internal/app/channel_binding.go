@@ -0,0 +1,47 @@
+package app
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"practical-case-test/config"
+)
+
+// ChannelBindingDomain is the domain-separation string hashed in front of the challenge of an interactive
+// login when it is bound to a TLS channel.
+const ChannelBindingDomain = "n-zkp-test/channel-binding/v1"
+
+// bindChallenge binds the challenge c of an interactive login to the TLS connection it was requested on,
+// whose exporter value (RFC 9266) is channelBinding, by hashing the group description, c and channelBinding
+// with SHA-512 and mapping the digest to the range randomChallenge draws c from. The prover answers the
+// bound challenge and the verifier checks the response against it, so a response relayed from another
+// connection, with another exporter value, does not verify. Without a channel binding c is returned as is.
+// It returns the errors of newGroup.
+func bindChallenge(cfg *config.Config, c *big.Int, channelBinding []byte) (*big.Int, error) {
+	if len(channelBinding) == 0 {
+		return c, nil
+	}
+	grp, _, _, err := newGroup(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	d := sha512.New()
+	writeFramed := func(b []byte) {
+		_ = binary.Write(d, binary.BigEndian, uint32(len(b)))
+		d.Write(b)
+	}
+	writeFramed([]byte(ChannelBindingDomain))
+	writeFramed([]byte(grp.Description()))
+	writeFramed(c.Bytes())
+	writeFramed(channelBinding)
+
+	upper := grp.Order()
+	if cfg.ChallengeBits != 0 && int(cfg.ChallengeBits) < upper.BitLen() {
+		upper = new(big.Int).Lsh(big.NewInt(1), cfg.ChallengeBits)
+	}
+	bound := new(big.Int).SetBytes(d.Sum(nil))
+	bound.Mod(bound, new(big.Int).Sub(upper, big.NewInt(1)))
+	return bound.Add(bound, big.NewInt(1)), nil
+}
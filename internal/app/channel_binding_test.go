@@ -0,0 +1,49 @@
+package app
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"practical-case-test/config"
+)
+
+func Test_bindChallenge(t *testing.T) {
+	t.Parallel()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	c := big.NewInt(31337)
+
+	unbound, err := bindChallenge(cfg, c, nil)
+	require.NoError(t, err)
+	require.Equal(t, c, unbound, "without a channel binding the challenge should not change")
+
+	bound, err := bindChallenge(cfg, c, []byte("channel A"))
+	require.NoError(t, err)
+	require.NotEqual(t, c, bound)
+	require.Positive(t, bound.Sign(), "bound challenge should not be zero")
+	require.Negative(t, bound.Cmp(cfg.Q), "bound challenge should be below q")
+	again, err := bindChallenge(cfg, c, []byte("channel A"))
+	require.NoError(t, err)
+	require.Equal(t, bound, again, "bound challenge should be deterministic")
+
+	other, err := bindChallenge(cfg, c, []byte("channel B"))
+	require.NoError(t, err)
+	require.NotEqual(t, bound, other, "bound challenge should depend on the channel")
+	other, err = bindChallenge(cfg, big.NewInt(31338), []byte("channel A"))
+	require.NoError(t, err)
+	require.NotEqual(t, bound, other, "bound challenge should depend on the challenge")
+
+	short := *cfg
+	short.ChallengeBits = 8
+	for _, binding := range []string{"channel A", "channel B", "channel C"} {
+		got, bindErr := bindChallenge(&short, c, []byte(binding))
+		require.NoError(t, bindErr)
+		require.Positive(t, got.Sign())
+		require.Less(t, got.Int64(), int64(256), "bound challenge should keep the configured length")
+	}
+
+	_, err = bindChallenge(&config.Config{Q: big.NewInt(0)}, c, []byte("channel A"))
+	require.Error(t, err)
+}
@@ -69,8 +69,6 @@ func generateRndCommitment(cfg *config.Config) (*big.Int, *big.Int, *big.Int, er
 		return nil, nil, nil, ErrConfigNil
 	}
 
-	slog.Info("generating commitment, cfg", "cfg", cfg)
-
 	r1, r2, k, err := calculateCommitment(cfg)
 	if err != nil {
 		return nil, nil, nil, err
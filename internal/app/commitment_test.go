@@ -9,7 +9,8 @@ import (
 )
 
 func TestCommitment_Exec(t *testing.T) {
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
 
 	testCases := []struct {
 		name    string
@@ -43,7 +44,8 @@ func TestCommitment_Exec(t *testing.T) {
 }
 
 func Test_generateRndCommitment(t *testing.T) {
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
 
 	testCases := []struct {
 		name    string
@@ -10,10 +10,12 @@ import (
 
 // ComputeSExecuter is an interface that defines the `Exec` method for executing the computational logic of `ComputeS` operation.
 //
-// The `Exec` method takes a `cfg` configuration object, `x` and `k` big integers, and a pointer to an `AuthenticationChallengeResponse` object.
+// The `Exec` method takes a `cfg` configuration object, `x` and `k` big integers, a pointer to an `AuthenticationChallengeResponse` object
+// and the TLS channel binding of the connection the challenge was received on.
 // It returns a big integer and an error.
 type ComputeSExecuter interface {
-	Exec(cfg *config.Config, x, k *big.Int, res *interactor.AuthenticationChallengeResponse) (*big.Int, error)
+	Exec(cfg *config.Config, x, k *big.Int, res *interactor.AuthenticationChallengeResponse, channelBinding []byte) (
+		*big.Int, error)
 }
 
 // ComputeS represents a type that computes the value of S based on the provided inputs.
@@ -25,18 +27,26 @@ func NewComputeS() ComputeSExecuter {
 }
 
 // Exec calculates the value of s by using the given configuration, x, k, and res parameters.
-// It calculates s using the formula: s = (k - (c * x)) mod q, where c is obtained from res.GetC().
+// It calculates s using the formula: s = (k - (c * x)) mod q, where c is obtained from res.GetC() and bound
+// to channelBinding with bindChallenge.
 // The function returns the calculated value of s and an error, if any.
 // If the configuration is nil, it returns nil and an error indicating that the config cannot be nil.
 // If the value of q in the configuration is zero, it returns nil and an error indicating that q cannot be zero.
-// The function logs the received value of c, k, and res before invoking the calculateS function to calculate s.
-func (ru ComputeS) Exec(cfg *config.Config, x, k *big.Int, res *interactor.AuthenticationChallengeResponse) (
+// The function logs the received value of c and res before invoking the calculateS function to calculate s. It
+// never logs k: together with c and the s sent to the verifier it gives away the secret x.
+func (ru ComputeS) Exec(cfg *config.Config, x, k *big.Int, res *interactor.AuthenticationChallengeResponse,
+	channelBinding []byte) (
 	*big.Int,
 	error,
 ) {
-	c := new(big.Int).SetInt64(res.GetC())
+	c := new(big.Int).SetBytes(res.GetCBytes())
 
-	slog.Info("received c", "c", c, "k", k, "res", res)
+	slog.Info("received c", "c", c, "res", res)
+
+	c, err := bindChallenge(cfg, c, channelBinding)
+	if err != nil {
+		return nil, err
+	}
 
 	s, err := calculateS(cfg, c, x, k)
 	if err != nil {
@@ -12,7 +12,8 @@ import (
 )
 
 func TestComputeS_Exec(t *testing.T) {
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
 	faultyCfg := &config.Config{Q: big.NewInt(0)}
 
 	compute := ComputeS{}
@@ -25,7 +26,7 @@ func TestComputeS_Exec(t *testing.T) {
 		name        string
 		x           *big.Int
 		k           *big.Int
-		c           int64
+		c           *big.Int
 		cfg         *config.Config
 		expectedErr error
 	}{
@@ -33,7 +34,7 @@ func TestComputeS_Exec(t *testing.T) {
 			name:        "Test zero values",
 			x:           big.NewInt(0),
 			k:           big.NewInt(0),
-			c:           0,
+			c:           big.NewInt(0),
 			cfg:         cfg,
 			expectedErr: nil,
 		},
@@ -41,7 +42,7 @@ func TestComputeS_Exec(t *testing.T) {
 			name:        "Test negative values",
 			x:           big.NewInt(-5),
 			k:           big.NewInt(-7),
-			c:           -11,
+			c:           big.NewInt(11),
 			cfg:         cfg,
 			expectedErr: nil,
 		},
@@ -49,7 +50,7 @@ func TestComputeS_Exec(t *testing.T) {
 			name:        "Test with x value being larger",
 			x:           big.NewInt(999),
 			k:           big.NewInt(7),
-			c:           12,
+			c:           big.NewInt(12),
 			cfg:         cfg,
 			expectedErr: nil,
 		},
@@ -57,7 +58,7 @@ func TestComputeS_Exec(t *testing.T) {
 			name:        "Test with k value being larger",
 			x:           big.NewInt(11),
 			k:           big.NewInt(99),
-			c:           33,
+			c:           big.NewInt(33),
 			cfg:         cfg,
 			expectedErr: nil,
 		},
@@ -65,7 +66,7 @@ func TestComputeS_Exec(t *testing.T) {
 			name:        "Test large values",
 			x:           bigNum,
 			k:           bigNum,
-			c:           math.MaxInt64,
+			c:           big.NewInt(math.MaxInt64),
 			cfg:         cfg,
 			expectedErr: nil,
 		},
@@ -74,7 +75,7 @@ func TestComputeS_Exec(t *testing.T) {
 			cfg:         faultyCfg,
 			x:           big.NewInt(11),
 			k:           big.NewInt(99),
-			c:           33,
+			c:           big.NewInt(33),
 			expectedErr: ErrZeroQ,
 		},
 	}
@@ -82,9 +83,9 @@ func TestComputeS_Exec(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			mockRes := &interactor.AuthenticationChallengeResponse{
-				C: tt.c,
+				CBytes: tt.c.Bytes(),
 			}
-			_, actualErr := compute.Exec(tt.cfg, tt.x, tt.k, mockRes)
+			_, actualErr := compute.Exec(tt.cfg, tt.x, tt.k, mockRes, nil)
 			if tt.expectedErr != nil {
 				require.ErrorIs(t, actualErr, tt.expectedErr, "Expected error of type %v, but got %v", tt.expectedErr, actualErr)
 				return
@@ -2,12 +2,11 @@ package app
 
 import (
 	"context"
-	"crypto/rand"
 	"log/slog"
-	"math"
 	"math/big"
 	"time"
 
+	"practical-case-test/config"
 	"practical-case-test/internal/domain/auth"
 	interactor "practical-case-test/internal/interactor/proto"
 	"practical-case-test/internal/repository"
@@ -15,7 +14,8 @@ import (
 
 // CreateAuthenticationChallengeExecuter is an interface that defines the method for executing the creation of an authentication challenge.
 type CreateAuthenticationChallengeExecuter interface {
-	Exec(ctx context.Context, req *interactor.AuthenticationChallengeRequest) (*auth.Challenge, error)
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.AuthenticationChallengeRequest, client auth.ClientInfo) (
+		*auth.Challenge, error)
 }
 
 // CreateAuthenticationChallenge is a type responsible for creating an authentication challenge.
@@ -30,11 +30,13 @@ func NewCreateAuthenticationChallenge(ar repository.AuthRepository) CreateAuthen
 }
 
 // Exec creates an Authentication Challenge for a user based on the provided request.
-// It generates a random challenge value and logs it.
-// The challenge request is then validated and stored in the repository.
+// It rejects commitments r1 and r2 that are not non-identity elements of the configured group with an
+// *InvalidElementError, then draws a random challenge with randomChallenge and logs it.
+// The challenge request is then validated, attributed to client and stored in the repository.
 // The generated challenge and any error that occurs during the process are returned.
 // If an error occurs during the process, the returned challenge will be nil.
-func (ru CreateAuthenticationChallenge) Exec(ctx context.Context, req *interactor.AuthenticationChallengeRequest) (
+func (ru CreateAuthenticationChallenge) Exec(ctx context.Context, cfg *config.Config, req *interactor.AuthenticationChallengeRequest,
+	client auth.ClientInfo) (
 	*auth.Challenge,
 	error,
 ) {
@@ -47,17 +49,26 @@ func (ru CreateAuthenticationChallenge) Exec(ctx context.Context, req *interacto
 		return nil, err
 	}
 
-	c, _ := rand.Int(rand.Reader, big.NewInt(math.MaxInt16))
-	c.Add(c, big.NewInt(1))
+	r1 := new(big.Int).SetBytes(req.GetR1Bytes())
+	r2 := new(big.Int).SetBytes(req.GetR2Bytes())
+	if err = validateElements(cfg, namedValue{"r1", r1}, namedValue{"r2", r2}); err != nil {
+		return nil, err
+	}
+
+	c, err := randomChallenge(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	slog.Info("random challenge generated", "c", c)
 
 	slog.Info("challenge request", "request", req)
 
-	challenge, err := auth.NewChallenge(c, userID, req.GetR1(), req.GetR2(), time.Now().Unix())
+	challenge, err := auth.NewChallenge(c, userID, r1, r2, time.Now().Unix())
 	if err != nil {
 		return nil, err
 	}
+	*challenge = challenge.WithClient(client)
 
 	err = ru.ar.StoreAuthenticationChallenge(ctx, *challenge)
 	if err != nil {
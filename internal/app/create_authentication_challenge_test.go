@@ -3,8 +3,10 @@ package app
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 
+	"practical-case-test/config"
 	"practical-case-test/internal/domain/auth"
 	interactor "practical-case-test/internal/interactor/proto"
 
@@ -13,24 +15,29 @@ import (
 )
 
 func TestCreateAuthenticationChallenge_Exec(t *testing.T) {
+	cfg := &config.Config{G: big.NewInt(4), H: big.NewInt(9), P: big.NewInt(23), Q: big.NewInt(11)}
+	r1, r2 := big.NewInt(12).Bytes(), big.NewInt(8).Bytes()
+	client := auth.NewClientInfo("192.0.2.1:54321", "zkp-prover", "phone")
+
 	testCases := []struct {
-		name          string
-		req           *interactor.AuthenticationChallengeRequest
-		mockGetUser   *auth.User
-		mockGetError  error
-		mockStoreErr  error
-		expectedError string
+		name           string
+		req            *interactor.AuthenticationChallengeRequest
+		mockGetUser    *auth.User
+		mockGetError   error
+		mockStoreErr   error
+		expectedError  string
+		invalidElement string
 	}{
 		{
 			name:         "Create auth challenge, successful case",
-			req:          &interactor.AuthenticationChallengeRequest{User: "testUser", R1: 0, R2: 0},
+			req:          &interactor.AuthenticationChallengeRequest{User: "testUser", R1Bytes: r1, R2Bytes: r2},
 			mockGetUser:  &auth.User{}, // valid user
 			mockGetError: nil,
 			mockStoreErr: nil,
 		},
 		{
 			name:          "GetUserRegistration returns error",
-			req:           &interactor.AuthenticationChallengeRequest{User: "testUser", R1: 0, R2: 0},
+			req:           &interactor.AuthenticationChallengeRequest{User: "testUser", R1Bytes: r1, R2Bytes: r2},
 			mockGetUser:   nil,
 			mockGetError:  errors.New("get user error"),
 			mockStoreErr:  nil,
@@ -38,12 +45,40 @@ func TestCreateAuthenticationChallenge_Exec(t *testing.T) {
 		},
 		{
 			name:          "StoreAuthenticationChallenge returns error",
-			req:           &interactor.AuthenticationChallengeRequest{User: "testUser", R1: 0, R2: 0},
+			req:           &interactor.AuthenticationChallengeRequest{User: "testUser", R1Bytes: r1, R2Bytes: r2},
 			mockGetUser:   &auth.User{}, // valid user
 			mockGetError:  nil,
 			mockStoreErr:  errors.New("store auth challenge error"),
 			expectedError: "store auth challenge error",
 		},
+		{
+			name:           "Zero r1",
+			req:            &interactor.AuthenticationChallengeRequest{User: "testUser", R1Bytes: nil, R2Bytes: r2},
+			mockGetUser:    &auth.User{},
+			expectedError:  "invalid r1: invalid group element",
+			invalidElement: "r1",
+		},
+		{
+			name:           "Identity r2",
+			req:            &interactor.AuthenticationChallengeRequest{User: "testUser", R1Bytes: r1, R2Bytes: []byte{1}},
+			mockGetUser:    &auth.User{},
+			expectedError:  "invalid r2: element is the identity",
+			invalidElement: "r2",
+		},
+		{
+			name:           "r1 not below p",
+			req:            &interactor.AuthenticationChallengeRequest{User: "testUser", R1Bytes: big.NewInt(35).Bytes(), R2Bytes: r2},
+			mockGetUser:    &auth.User{},
+			expectedError:  "invalid r1: invalid group element",
+			invalidElement: "r1",
+		},
+		{
+			name:           "r2 outside the order-q subgroup",
+			req:            &interactor.AuthenticationChallengeRequest{User: "testUser", R1Bytes: r1, R2Bytes: big.NewInt(22).Bytes()},
+			mockGetUser:    &auth.User{},
+			expectedError:  "invalid r2: invalid group element",
+			invalidElement: "r2",
+		},
 	}
 
 	for _, tt := range testCases {
@@ -52,19 +87,64 @@ func TestCreateAuthenticationChallenge_Exec(t *testing.T) {
 			ar := new(mockAuthRepository)
 			creator := NewCreateAuthenticationChallenge(ar)
 			ar.On("GetUserRegistration", mock.Anything, tt.req.GetUser()).Return(tt.mockGetUser, tt.mockGetError)
-			if tt.mockGetError == nil {
+			if tt.mockGetError == nil && tt.invalidElement == "" {
 				ar.On("StoreAuthenticationChallenge", mock.Anything, mock.Anything).Return(tt.mockStoreErr)
 			}
 
-			_, err := creator.Exec(context.Background(), tt.req)
+			challenge, err := creator.Exec(context.Background(), cfg, tt.req, client)
 
 			if tt.expectedError != "" {
 				require.Error(t, err, "Expected an error but got none")
 				require.Equal(t, tt.expectedError, err.Error(), "Expected error of type %v, but got type %v", tt.expectedError, err.Error())
+				if tt.invalidElement != "" {
+					var invalid *InvalidElementError
+					require.ErrorAs(t, err, &invalid)
+					require.Equal(t, tt.invalidElement, invalid.Name)
+				}
 			} else {
 				require.NoError(t, err, "Did not expect an error but got %v", err)
+				require.Equal(t, client, challenge.Client())
 			}
 			ar.AssertExpectations(t)
 		})
 	}
 }
+
+func Test_randomChallenge(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	withBits := func(bits uint) *config.Config {
+		c := *cfg
+		c.ChallengeBits = bits
+		return &c
+	}
+
+	testCases := []struct {
+		name  string
+		cfg   *config.Config
+		upper *big.Int
+	}{
+		{name: "Full range by default", cfg: cfg, upper: cfg.Q},
+		{name: "128-bit challenges", cfg: withBits(128), upper: new(big.Int).Lsh(big.NewInt(1), 128)},
+		{name: "8-bit challenges", cfg: withBits(8), upper: big.NewInt(256)},
+		{name: "More bits than q falls back to the full range", cfg: withBits(4096), upper: cfg.Q},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			maxBits := 0
+			for range 64 {
+				c, err := randomChallenge(tt.cfg)
+				require.NoError(t, err)
+				require.Positive(t, c.Sign(), "challenge should not be zero")
+				require.Negative(t, c.Cmp(tt.upper), "challenge should be below %v", tt.upper)
+				maxBits = max(maxBits, c.BitLen())
+			}
+			// With 64 draws the largest challenge is within a few bits of the upper bound.
+			require.Greater(t, maxBits, tt.upper.BitLen()-8, "challenges should span the whole range")
+		})
+	}
+
+	_, err = randomChallenge(&config.Config{Q: big.NewInt(0)})
+	require.ErrorIs(t, err, ErrZeroQ)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrInvalidKDFParams is an error indicating that the Argon2id cost parameters are out of range.
+// ErrKDFCostTooHigh is an error indicating that the Argon2id cost parameters exceed the limits of the
+// configuration.
+// ErrShortSalt is an error indicating that the salt is shorter than auth.MinSaltLength.
+var (
+	ErrInvalidKDFParams = errors.New("argon2 time, memory and threads must be positive and threads at most 255")
+	ErrKDFCostTooHigh   = errors.New("argon2 time, memory or threads exceed the configured limits")
+	ErrShortSalt        = errors.New("salt is too short")
+)
+
+// kdfExtraBytes is the number of bytes derived beyond the length of the group order, so that reducing
+// the derived key modulo the order leaves a negligible bias.
+const kdfExtraBytes = 16
+
+// DeriveSecretExecuter is an interface that defines the `Exec` method for deriving the secret x of a
+// user from a password.
+//
+// The `Exec` method takes a `cfg` configuration object, the password, the salt of the user and the Argon2id
+// cost parameters, and returns the secret as a big integer and an error.
+type DeriveSecretExecuter interface {
+	Exec(cfg *config.Config, password string, salt []byte, params auth.KDFParams) (*big.Int, error)
+}
+
+// DeriveSecret represents a type that derives the secret x from a password with Argon2id, so that a
+// user can authenticate from any machine knowing only the password.
+type DeriveSecret struct{}
+
+// NewDeriveSecret returns a new instance of DeriveSecretExecuter.
+func NewDeriveSecret() DeriveSecretExecuter {
+	return &DeriveSecret{}
+}
+
+// Exec derives the secret x from the password and the salt with Argon2id, using the cost parameters params:
+// those of the configuration when registering, and those the user registered with, as returned by GetSalt,
+// when logging in. It derives kdfExtraBytes more bytes than the group order takes and reduces them
+// modulo the order, so x is close to uniform in [0, q).
+// It returns ErrShortSalt if the salt is shorter than auth.MinSaltLength, ErrInvalidKDFParams if the
+// cost parameters are out of range, ErrKDFCostTooHigh if they exceed the limits of cfg, so that a
+// verifier cannot make the prover exhaust its memory, or the errors of newGroup.
+func (ds DeriveSecret) Exec(cfg *config.Config, password string, salt []byte, params auth.KDFParams) (*big.Int, error) {
+	grp, _, _, err := newGroup(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(salt) < auth.MinSaltLength {
+		return nil, ErrShortSalt
+	}
+	if !params.IsValid() {
+		return nil, ErrInvalidKDFParams
+	}
+	if err = checkKDFCost(cfg, params); err != nil {
+		return nil, err
+	}
+
+	keyLen := (grp.Order().BitLen()+7)/8 + kdfExtraBytes
+	key := argon2.IDKey([]byte(password), salt, params.Time, params.MemoryKiB, uint8(params.Threads), uint32(keyLen))
+
+	x := new(big.Int).SetBytes(key)
+	return x.Mod(x, grp.Order()), nil
+}
+
+// KDFParams returns the Argon2id cost parameters of the configuration, which new users are registered with,
+// or zero parameters for a nil configuration.
+func KDFParams(cfg *config.Config) auth.KDFParams {
+	if cfg == nil {
+		return auth.KDFParams{}
+	}
+	return auth.KDFParams{Time: cfg.Argon2Time, MemoryKiB: cfg.Argon2MemoryKiB, Threads: cfg.Argon2Threads}
+}
+
+// checkKDFCost returns ErrKDFCostTooHigh if any of the Argon2id cost parameters exceeds the limit
+// cfg sets for it.
+func checkKDFCost(cfg *config.Config, params auth.KDFParams) error {
+	if params.Time > cfg.Argon2MaxTime || params.MemoryKiB > cfg.Argon2MaxMemoryKiB ||
+		params.Threads > cfg.Argon2MaxThreads {
+		return ErrKDFCostTooHigh
+	}
+	return nil
+}
+
+// NewSalt returns a random salt of auth.MinSaltLength bytes to derive the secret of a new user with.
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, auth.MinSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSalt = bytes.Repeat([]byte{0x5a}, auth.MinSaltLength)
+
+// testKDF are cheap Argon2id parameters that keep the tests fast; the derivation does not depend on them otherwise.
+var testKDF = auth.KDFParams{Time: 1, MemoryKiB: 64, Threads: 1}
+
+func TestDeriveSecret_Exec(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		salt    []byte
+		params  auth.KDFParams
+		wantErr error
+	}{
+		{
+			name:   "Valid case",
+			cfg:    cfg,
+			salt:   testSalt,
+			params: testKDF,
+		},
+		{
+			name:    "Invalid case: no Config",
+			cfg:     nil,
+			salt:    testSalt,
+			params:  testKDF,
+			wantErr: ErrNilConfig,
+		},
+		{
+			name:    "Invalid case: short salt",
+			cfg:     cfg,
+			salt:    testSalt[:auth.MinSaltLength-1],
+			params:  testKDF,
+			wantErr: ErrShortSalt,
+		},
+		{
+			name:    "Invalid case: zero time",
+			cfg:     cfg,
+			salt:    testSalt,
+			params:  auth.KDFParams{Time: 0, MemoryKiB: 64, Threads: 1},
+			wantErr: ErrInvalidKDFParams,
+		},
+		{
+			name:    "Invalid case: too many threads",
+			cfg:     cfg,
+			salt:    testSalt,
+			params:  auth.KDFParams{Time: 1, MemoryKiB: 64, Threads: math.MaxUint8 + 1},
+			wantErr: ErrInvalidKDFParams,
+		},
+		{
+			name:    "Invalid case: memory above the limit",
+			cfg:     cfg,
+			salt:    testSalt,
+			params:  auth.KDFParams{Time: 1, MemoryKiB: cfg.Argon2MaxMemoryKiB + 1, Threads: 1},
+			wantErr: ErrKDFCostTooHigh,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			x, err := NewDeriveSecret().Exec(tt.cfg, "correct horse battery staple", tt.salt, tt.params)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Negative(t, x.Cmp(tt.cfg.Q), "secret should be reduced modulo q")
+		})
+	}
+
+	t.Run("Deterministic per password and salt", func(t *testing.T) {
+		t.Parallel()
+		ds := NewDeriveSecret()
+		derive := func(password string, salt []byte, params auth.KDFParams) *big.Int {
+			x, err := ds.Exec(cfg, password, salt, params)
+			require.NoError(t, err)
+			return x
+		}
+		otherSalt := bytes.Repeat([]byte{0xa5}, auth.MinSaltLength)
+		otherKDF := auth.KDFParams{Time: 2, MemoryKiB: 64, Threads: 1}
+
+		require.Equal(t, derive("password", testSalt, testKDF), derive("password", testSalt, testKDF))
+		require.NotEqual(t, derive("password", testSalt, testKDF), derive("passwore", testSalt, testKDF))
+		require.NotEqual(t, derive("password", testSalt, testKDF), derive("password", otherSalt, testKDF))
+		require.NotEqual(t, derive("password", testSalt, testKDF), derive("password", testSalt, otherKDF))
+	})
+
+	t.Run("Independent of the configured cost", func(t *testing.T) {
+		t.Parallel()
+		other := *cfg
+		other.Argon2Time, other.Argon2MemoryKiB, other.Argon2Threads = 1, 8, 2
+		x, err := NewDeriveSecret().Exec(cfg, "password", testSalt, testKDF)
+		require.NoError(t, err)
+		y, err := NewDeriveSecret().Exec(&other, "password", testSalt, testKDF)
+		require.NoError(t, err)
+		require.Equal(t, x, y, "the registered cost should decide the secret, not the local configuration")
+	})
+}
+
+func TestKDFParams(t *testing.T) {
+	cfg := &config.Config{Argon2Time: 3, Argon2MemoryKiB: 65536, Argon2Threads: 4}
+	require.Equal(t, auth.KDFParams{Time: 3, MemoryKiB: 65536, Threads: 4}, KDFParams(cfg))
+}
+
+func TestNewSalt(t *testing.T) {
+	a, err := NewSalt()
+	require.NoError(t, err)
+	b, err := NewSalt()
+	require.NoError(t, err)
+	require.Len(t, a, auth.MinSaltLength)
+	require.NotEqual(t, a, b, "salts should be random")
+}
+
+func TestRandomPassword(t *testing.T) {
+	a, err := RandomPassword()
+	require.NoError(t, err)
+	b, err := RandomPassword()
+	require.NoError(t, err)
+	require.Len(t, a, randomPasswordLength)
+	require.NotEqual(t, a, b, "passwords should be random")
+}
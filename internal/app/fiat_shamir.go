@@ -0,0 +1,57 @@
+package app
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"practical-case-test/config"
+)
+
+// FiatShamirDomain is the domain-separation string hashed in front of every
+// Fiat-Shamir transcript, so that challenges cannot collide with hashes computed
+// for other purposes.
+const FiatShamirDomain = "n-zkp-test/fiat-shamir/v2"
+
+// fiatShamirChallenge derives the challenge of a non-interactive proof by hashing the
+// group description, the generators g and h, the user ID, y1, y2, r1, r2, the
+// timestamp of the proof and the channel binding of the TLS connection the proof is
+// sent on, empty without TLS, with SHA-512. Binding the connection makes a proof
+// relayed from another connection fail. Every element is hashed in its fixed-length
+// group encoding and every variable-length field is length-prefixed, so distinct
+// transcripts never hash the same input. The digest is mapped to [1, q) so the
+// challenge is never zero.
+// It returns the errors of newGroup, or group.ErrInvalidElement if one of the values
+// is not a group element.
+func fiatShamirChallenge(cfg *config.Config, userID string, y1, y2, r1, r2 *big.Int, timestamp int64,
+	channelBinding []byte) (*big.Int, error) {
+	grp, g, h, err := newGroup(cfg)
+	if err != nil {
+		return nil, err
+	}
+	elems, err := intsToElements(grp, y1, y2, r1, r2)
+	if err != nil {
+		return nil, err
+	}
+
+	d := sha512.New()
+	writeFramed := func(b []byte) {
+		_ = binary.Write(d, binary.BigEndian, uint32(len(b)))
+		d.Write(b)
+	}
+	writeFramed([]byte(FiatShamirDomain))
+	writeFramed([]byte(grp.Description()))
+	writeFramed(grp.Encode(g))
+	writeFramed(grp.Encode(h))
+	writeFramed([]byte(userID))
+	for _, e := range elems {
+		writeFramed(grp.Encode(e))
+	}
+	_ = binary.Write(d, binary.BigEndian, timestamp)
+	writeFramed(channelBinding)
+
+	qMinusOne := new(big.Int).Sub(grp.Order(), big.NewInt(1))
+	c := new(big.Int).SetBytes(d.Sum(nil))
+	c.Mod(c, qMinusOne)
+	return c.Add(c, big.NewInt(1)), nil
+}
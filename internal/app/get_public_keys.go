@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/pkg/sessionjwt"
+)
+
+// GetPublicKeysExecuter is an interface that defines the method for publishing the keys signed session
+// tokens are verified with.
+type GetPublicKeysExecuter interface {
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.GetPublicKeysRequest) (*sessionjwt.KeySet, error)
+}
+
+// GetPublicKeys is a type that is responsible for handing out the public key of the verifier's signing
+// key, so that other services can verify signed session tokens offline.
+type GetPublicKeys struct{}
+
+// NewGetPublicKeys creates a new instance of GetPublicKeysExecuter.
+func NewGetPublicKeys() GetPublicKeysExecuter {
+	return &GetPublicKeys{}
+}
+
+// Exec returns the key set holding the public key of cfg.SessionJWTKey, or an empty key set if the
+// verifier does not issue signed tokens.
+// It returns ErrNilConfig, or sessionjwt.ErrInvalidKey if SessionJWT is set without a valid key.
+func (GetPublicKeys) Exec(_ context.Context, cfg *config.Config, _ *interactor.GetPublicKeysRequest) (
+	*sessionjwt.KeySet, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	if !cfg.SessionJWT {
+		return sessionjwt.NewKeySet()
+	}
+	if len(cfg.SessionJWTKey) != ed25519.PrivateKeySize {
+		return nil, sessionjwt.ErrInvalidKey
+	}
+	public, ok := cfg.SessionJWTKey.Public().(ed25519.PublicKey)
+	if !ok {
+		return nil, sessionjwt.ErrInvalidKey
+	}
+	return sessionjwt.NewKeySet(public)
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/pkg/sessionjwt"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPublicKeys_Exec(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	withKey, err := sessionjwt.NewKeySet(public)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		want    *sessionjwt.KeySet
+		wantErr error
+	}{
+		{
+			name: "Signing key",
+			cfg:  &config.Config{SessionJWT: true, SessionJWTKey: private},
+			want: withKey,
+		},
+		{
+			name: "Signed tokens disabled",
+			cfg:  &config.Config{SessionJWTKey: private},
+			want: &sessionjwt.KeySet{Keys: []sessionjwt.JWK{}},
+		},
+		{
+			name:    "Signed tokens without key",
+			cfg:     &config.Config{SessionJWT: true},
+			wantErr: sessionjwt.ErrInvalidKey,
+		},
+		{
+			name:    "Nil config",
+			wantErr: ErrNilConfig,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewGetPublicKeys().Exec(context.Background(), tt.cfg, &interactor.GetPublicKeysRequest{})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
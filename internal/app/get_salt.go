@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"log/slog"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+)
+
+// fakeSaltDomain separates the salts GetSalt makes up for unknown users from the other values keyed with the
+// session key.
+const fakeSaltDomain = "zkp-auth/unknown-user-salt/v1\x00"
+
+// GetSaltExecuter is an interface that defines the method for looking up the salt and the Argon2id cost
+// parameters of a registered user.
+type GetSaltExecuter interface {
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.SaltRequest) ([]byte, auth.KDFParams, error)
+}
+
+// GetSalt is a type that is responsible for handing a registered user's salt and Argon2id cost parameters
+// back to the prover, which needs them to derive its secret from the password before logging in.
+type GetSalt struct {
+	ar repository.AuthRepository
+}
+
+// NewGetSalt creates a new instance of GetSaltExecuter with the provided AuthRepository.
+func NewGetSalt(ar repository.AuthRepository) GetSaltExecuter {
+	return &GetSalt{ar: ar}
+}
+
+// Exec loads the registration of the user named in the request and returns the salt and the Argon2id cost
+// parameters stored with it. For a user that is not registered it returns the salt made up by fakeSalt and
+// the cost of cfg instead of an error, so that the answer does not tell whether a user name is registered.
+// It returns the error of the repository if the user cannot be loaded for any other reason.
+func (gs GetSalt) Exec(ctx context.Context, cfg *config.Config, req *interactor.SaltRequest) ([]byte, auth.KDFParams,
+	error) {
+	userID := req.GetUser()
+
+	slog.Info("looking up salt", "user", userID)
+
+	user, err := gs.ar.GetUserRegistration(ctx, userID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		salt, err := fakeSalt(cfg, userID)
+		if err != nil {
+			return nil, auth.KDFParams{}, err
+		}
+		return salt, KDFParams(cfg), nil
+	}
+	if err != nil {
+		return nil, auth.KDFParams{}, err
+	}
+
+	return user.Salt(), user.KDFParams(), nil
+}
+
+// fakeSalt returns the salt GetSalt hands out for the unknown user userID: the first auth.MinSaltLength bytes
+// of the HMAC-SHA256 of the user name under cfg.SessionKey. It is the same on every call, like a stored salt,
+// but cannot be computed without the key. It returns ErrNilConfig for a nil configuration and
+// auth.ErrInvalidSessionKey if cfg has no session key.
+func fakeSalt(cfg *config.Config, userID string) ([]byte, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	if len(cfg.SessionKey) == 0 {
+		return nil, auth.ErrInvalidSessionKey
+	}
+	mac := hmac.New(sha256.New, cfg.SessionKey)
+	mac.Write([]byte(fakeSaltDomain))
+	mac.Write([]byte(userID))
+	return mac.Sum(nil)[:auth.MinSaltLength], nil
+}
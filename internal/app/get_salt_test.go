@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSalt_Exec(t *testing.T) {
+	user, err := auth.NewUser("UserID1", big.NewInt(18), big.NewInt(16), testSalt, testKDF)
+	require.NoError(t, err)
+	cfg := &config.Config{SessionKey: testSessionKey, Argon2Time: 3, Argon2MemoryKiB: 65536, Argon2Threads: 4}
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		setup   func(ar *mockAuthRepository)
+		want    []byte
+		wantKDF auth.KDFParams
+		wantErr error
+	}{
+		{
+			name: "Successful Path",
+			cfg:  cfg,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), "UserID1").Return(user, nil)
+			},
+			want:    testSalt,
+			wantKDF: testKDF,
+		},
+		{
+			name: "Unknown user",
+			cfg:  cfg,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), "UserID1").Return(nil, repository.ErrUserNotFound)
+			},
+			want:    mustFakeSalt(t, cfg, "UserID1"),
+			wantKDF: KDFParams(cfg),
+		},
+		{
+			name: "Unknown user without a session key",
+			cfg:  &config.Config{},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), "UserID1").Return(nil, repository.ErrUserNotFound)
+			},
+			wantErr: auth.ErrInvalidSessionKey,
+		},
+		{
+			name: "GetUserRegistration fails",
+			cfg:  cfg,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), "UserID1").Return(nil, errors.New("GetUserRegistration error"))
+			},
+			wantErr: errors.New("GetUserRegistration error"),
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ar := new(mockAuthRepository)
+			tt.setup(ar)
+			salt, kdf, err := NewGetSalt(ar).Exec(context.Background(), tt.cfg, &interactor.SaltRequest{User: "UserID1"})
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, salt)
+				require.Equal(t, tt.wantKDF, kdf)
+			}
+			ar.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFakeSalt(t *testing.T) {
+	cfg := &config.Config{SessionKey: testSessionKey}
+
+	salt := mustFakeSalt(t, cfg, "alice")
+	require.Len(t, salt, auth.MinSaltLength, "a made-up salt should look like a stored one")
+	require.Equal(t, salt, mustFakeSalt(t, cfg, "alice"), "a made-up salt should not change between calls")
+	require.NotEqual(t, salt, mustFakeSalt(t, cfg, "bob"), "a made-up salt should depend on the user")
+
+	other := &config.Config{SessionKey: append([]byte{0x01}, testSessionKey[1:]...)}
+	require.NotEqual(t, salt, mustFakeSalt(t, other, "alice"), "a made-up salt should depend on the key")
+
+	_, err := fakeSalt(nil, "alice")
+	require.ErrorIs(t, err, ErrNilConfig)
+}
+
+func mustFakeSalt(t *testing.T, cfg *config.Config, userID string) []byte {
+	t.Helper()
+	salt, err := fakeSalt(cfg, userID)
+	require.NoError(t, err)
+	return salt
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+)
+
+// ListSessionsExecuter is an interface that defines the method for listing the active sessions of a user.
+type ListSessionsExecuter interface {
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.ListSessionsRequest, client auth.ClientInfo) (
+		[]SessionStatus, error)
+}
+
+// ListSessions is a type that is responsible for showing users, or admins, which sessions of a user are open.
+type ListSessions struct {
+	ar repository.AuthRepository
+}
+
+// NewListSessions creates a new instance of ListSessionsExecuter with the provided AuthRepository.
+func NewListSessions(ar repository.AuthRepository) ListSessionsExecuter {
+	return &ListSessions{ar: ar}
+}
+
+// Exec authorizes the request of client with authorizeSessionAccess and returns the sessions of the target user that have
+// not expired, ordered by login time, marking the one the request was made with as Current.
+// It returns the errors of authorizeSessionAccess or of the repository.
+func (ls ListSessions) Exec(ctx context.Context, cfg *config.Config,
+	req *interactor.ListSessionsRequest, client auth.ClientInfo) ([]SessionStatus, error) {
+	target, current, err := authorizeSessionAccess(ctx, ls.ar, cfg, req.GetUser(), req.GetSessionId(), req.GetTargetUser(),
+		client)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := ls.ar.ListSessions(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := make([]SessionStatus, 0, len(sessions))
+	for i := range sessions {
+		expiresAt := sessions[i].ExpiresAt(cfg.SessionTTL, cfg.SessionIdleTTL)
+		if !now.Before(expiresAt) {
+			continue
+		}
+		active = append(active, SessionStatus{
+			Valid:     true,
+			Session:   &sessions[i],
+			ExpiresAt: expiresAt.Unix(),
+			Current:   sessions[i].ID() == current,
+		})
+	}
+
+	slog.Info("sessions listed", "user", req.GetUser(), "target", target, "count", len(active))
+
+	return active, nil
+}
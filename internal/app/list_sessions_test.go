@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSessions_Exec(t *testing.T) {
+	cfg := &config.Config{
+		SessionKey:     testSessionKey,
+		SessionTTL:     time.Hour,
+		SessionIdleTTL: 30 * time.Minute,
+		AdminUsers:     []string{"admin"},
+	}
+
+	uID := "UserID1"
+	token, sessionID := newTestSession(t)
+	now := time.Now()
+	current, err := auth.NewSession(sessionID, uID, now.Add(-10*time.Minute).Unix())
+	require.NoError(t, err)
+	other, err := auth.NewSession(newTestSessionHash(t), uID, now.Add(-5*time.Minute).Unix())
+	require.NoError(t, err)
+	idle, err := auth.NewSession(newTestSessionHash(t), uID, now.Add(-40*time.Minute).Unix())
+	require.NoError(t, err)
+	adminSession, err := auth.NewSession(sessionID, "admin", now.Unix())
+	require.NoError(t, err)
+	adminClient := auth.ClientInfo{}.WithCertificateName("admin")
+	*adminSession = adminSession.WithClient(adminClient)
+
+	req := &interactor.ListSessionsRequest{User: uID, SessionId: token}
+	errRepository := errors.New("repository unavailable")
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		request *interactor.ListSessionsRequest
+		client  auth.ClientInfo
+		setup   func(ar *mockAuthRepository)
+		want    []SessionStatus
+		wantErr error
+	}{
+		{
+			name:    "Own sessions without the expired ones",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(current, nil)
+				ar.On("ListSessions", context.Background(), uID).Return([]auth.Session{*idle, *current, *other}, nil)
+			},
+			want: []SessionStatus{
+				{Valid: true, Session: current, ExpiresAt: now.Add(20 * time.Minute).Unix(), Current: true},
+				{Valid: true, Session: other, ExpiresAt: now.Add(25 * time.Minute).Unix()},
+			},
+		},
+		{
+			name:    "Own sessions named as target",
+			cfg:     cfg,
+			request: &interactor.ListSessionsRequest{User: uID, SessionId: token, TargetUser: uID},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(current, nil)
+				ar.On("ListSessions", context.Background(), uID).Return([]auth.Session{*current}, nil)
+			},
+			want: []SessionStatus{{Valid: true, Session: current, ExpiresAt: now.Add(20 * time.Minute).Unix(), Current: true}},
+		},
+		{
+			name:    "Admin lists another user",
+			cfg:     cfg,
+			request: &interactor.ListSessionsRequest{User: "admin", SessionId: token, TargetUser: uID},
+			client:  adminClient,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), "admin", sessionID).Return(adminSession, nil)
+				ar.On("ListSessions", context.Background(), uID).Return([]auth.Session{*other}, nil)
+			},
+			want: []SessionStatus{{Valid: true, Session: other, ExpiresAt: now.Add(25 * time.Minute).Unix()}},
+		},
+		{
+			name:    "No sessions",
+			cfg:     cfg,
+			request: &interactor.ListSessionsRequest{User: "admin", SessionId: token, TargetUser: "nobody"},
+			client:  adminClient,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), "admin", sessionID).Return(adminSession, nil)
+				ar.On("ListSessions", context.Background(), "nobody").Return([]auth.Session{}, nil)
+			},
+			want: []SessionStatus{},
+		},
+		{
+			name:    "Admin session replayed without a client certificate",
+			cfg:     cfg,
+			request: &interactor.ListSessionsRequest{User: "admin", SessionId: token, TargetUser: uID},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), "admin", sessionID).Return(adminSession, nil)
+			},
+			wantErr: ErrAdminCertificateRequired,
+		},
+		{
+			name:    "Admin session replayed with the client certificate of another user",
+			cfg:     cfg,
+			request: &interactor.ListSessionsRequest{User: "admin", SessionId: token, TargetUser: uID},
+			client:  auth.ClientInfo{}.WithCertificateName(uID),
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), "admin", sessionID).Return(adminSession, nil)
+			},
+			wantErr: ErrAdminCertificateRequired,
+		},
+		{
+			name:    "Other user without admin rights",
+			cfg:     cfg,
+			request: &interactor.ListSessionsRequest{User: uID, SessionId: token, TargetUser: "admin"},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(current, nil)
+			},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name:    "Unknown caller session",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(nil, repository.ErrSessionNotFound)
+			},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "Expired caller session",
+			cfg:     cfg,
+			request: &interactor.ListSessionsRequest{User: uID, SessionId: token},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(idle, nil)
+			},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "Malformed session ID",
+			cfg:     cfg,
+			request: &interactor.ListSessionsRequest{User: uID, SessionId: "not-a-token"},
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrInvalidSessionID,
+		},
+		{
+			name:    "Repository failure",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(current, nil)
+				ar.On("ListSessions", context.Background(), uID).Return(nil, errRepository)
+			},
+			wantErr: errRepository,
+		},
+		{
+			name:    "Nil config",
+			request: req,
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrNilConfig,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ar := new(mockAuthRepository)
+			tt.setup(ar)
+			got, err := NewListSessions(ar).Exec(context.Background(), tt.cfg, tt.request, tt.client)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+			ar.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+)
+
+// ErrProofExpired is an error indicating that the timestamp of a non-interactive proof is
+// further from the verifier's clock than the configured maximum skew.
+// ErrInvalidProof is an error indicating that a non-interactive proof does not verify.
+var (
+	ErrProofExpired = errors.New("proof timestamp outside of the accepted window")
+	ErrInvalidProof = errors.New("verification failed, invalid proof")
+)
+
+// LoginNonInteractiveExecuter is an interface that defines the contract for verifying a
+// non-interactive proof and opening a session.
+type LoginNonInteractiveExecuter interface {
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.NonInteractiveLoginRequest, client auth.ClientInfo) (
+		*IssuedSession, error)
+}
+
+// LoginNonInteractive is a type that is responsible for authenticating a user from a
+// Fiat-Shamir proof in a single round trip, without storing any challenge.
+type LoginNonInteractive struct {
+	ar repository.AuthRepository
+}
+
+// NewLoginNonInteractive creates a new instance of LoginNonInteractiveExecuter
+// with the provided AuthRepository.
+func NewLoginNonInteractive(ar repository.AuthRepository) LoginNonInteractiveExecuter {
+	return &LoginNonInteractive{ar: ar}
+}
+
+// Exec checks that the timestamp of the proof lies within cfg.FiatShamirMaxSkew of the
+// current time and that r1 and r2 are non-identity group elements, loads the registration
+// of the user, recomputes the challenge from the transcript and the channel binding of client
+// with fiatShamirChallenge and verifies the response s against it. It then records the proof with
+// AuthRepository.RecordProof, so that each proof opens at most one session: the timestamp window alone
+// would let anyone who saw a proof replay it until it leaves the window.
+// On success it issues a new session for the user with issueSession, attributed to client, and returns it
+// with its token.
+// It returns ErrProofExpired for a stale or future timestamp, an *InvalidElementError for
+// degenerate commitments, ErrInvalidProof if the proof does not verify, repository.ErrProofReplayed if
+// it was used before, or the error of the repository.
+func (ln LoginNonInteractive) Exec(ctx context.Context, cfg *config.Config,
+	req *interactor.NonInteractiveLoginRequest, client auth.ClientInfo) (*IssuedSession, error) {
+	userID := req.GetUser()
+	r1 := new(big.Int).SetBytes(req.GetR1())
+	r2 := new(big.Int).SetBytes(req.GetR2())
+	s := new(big.Int).SetBytes(req.GetS())
+
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	now := time.Now()
+	if skew := now.Sub(time.Unix(req.GetTimestamp(), 0)).Abs(); skew > cfg.FiatShamirMaxSkew {
+		return nil, ErrProofExpired
+	}
+
+	if err := validateElements(cfg, namedValue{"r1", r1}, namedValue{"r2", r2}); err != nil {
+		return nil, err
+	}
+
+	user, err := ln.ar.GetUserRegistration(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := fiatShamirChallenge(cfg, userID, user.Y1(), user.Y2(), r1, r2, req.GetTimestamp(),
+		client.ChannelBinding())
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := auth.NewChallenge(c, userID, r1, r2, req.GetTimestamp())
+	if err != nil {
+		return nil, err
+	}
+
+	if ok := verifyS(cfg, challenge, user, s); !ok {
+		return nil, ErrInvalidProof
+	}
+
+	if err = ln.ar.RecordProof(ctx, proofID(userID, r1, r2, req.GetTimestamp()), req.GetTimestamp()); err != nil {
+		return nil, err
+	}
+
+	issued, err := issueSession(ctx, ln.ar, cfg, userID, now.Unix(), client)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("session initiated from non-interactive proof", "user", userID, "session", issued.Session.ID(),
+		"peer", client.PeerAddress(), "device", client.DeviceLabel())
+
+	return issued, nil
+}
+
+// proofID identifies a non-interactive proof by the hex-encoded SHA-256 hash of the user, the commitments r1
+// and r2 and the timestamp, which together determine the challenge and with it the only valid response.
+func proofID(userID string, r1, r2 *big.Int, timestamp int64) string {
+	d := sha256.New()
+	for _, b := range [][]byte{[]byte(userID), r1.Bytes(), r2.Bytes()} {
+		_ = binary.Write(d, binary.BigEndian, uint32(len(b)))
+		d.Write(b)
+	}
+	_ = binary.Write(d, binary.BigEndian, timestamp)
+	return hex.EncodeToString(d.Sum(nil))
+}
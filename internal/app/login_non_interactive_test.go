@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+)
+
+func TestLoginNonInteractive_Exec(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	cfg.SessionKey = testSessionKey
+
+	uID := "UserID1"
+	x := big.NewInt(123456789)
+	y1, y2, err := calculateYs(cfg, x)
+	require.NoError(t, err)
+	user, _ := auth.NewUser(uID, y1, y2, testSalt, testKDF)
+
+	now := time.Now().Unix()
+	proof, err := NewProveNonInteractive().Exec(cfg, uID, x, now, nil)
+	require.NoError(t, err)
+	stale, err := NewProveNonInteractive().Exec(cfg, uID, x, now-int64(2*cfg.FiatShamirMaxSkew/time.Second), nil)
+	require.NoError(t, err)
+
+	toRequest := func(user string, p *NonInteractiveProof) *interactor.NonInteractiveLoginRequest {
+		return &interactor.NonInteractiveLoginRequest{
+			User:      user,
+			R1:        p.R1.Bytes(),
+			R2:        p.R2.Bytes(),
+			S:         p.S.Bytes(),
+			Timestamp: p.Timestamp,
+		}
+	}
+	req := toRequest(uID, proof)
+	reqID := proofID(uID, proof.R1, proof.R2, now)
+	client := auth.NewClientInfo("192.0.2.1:54321", "zkp-prover", "phone")
+
+	testCases := []struct {
+		name    string
+		request *interactor.NonInteractiveLoginRequest
+		setup   func(ar *mockAuthRepository)
+		wantErr error
+	}{
+		{
+			name:    "Successful Path",
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+				ar.On("RecordProof", context.Background(), reqID, now).Return(nil)
+				ar.On("StoreSession", context.Background(), mock.MatchedBy(func(s auth.Session) bool {
+					return s.Client() == client
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "Replayed proof",
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+				ar.On("RecordProof", context.Background(), reqID, now).Return(repository.ErrProofReplayed)
+			},
+			wantErr: repository.ErrProofReplayed,
+		},
+		{
+			name:    "Stale timestamp",
+			request: toRequest(uID, stale),
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrProofExpired,
+		},
+		{
+			name: "Timestamp changed after proving",
+			request: &interactor.NonInteractiveLoginRequest{
+				User: uID, R1: req.GetR1(), R2: req.GetR2(), S: req.GetS(), Timestamp: now + 1,
+			},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+			},
+			wantErr: ErrInvalidProof,
+		},
+		{
+			name: "Invalid s",
+			request: &interactor.NonInteractiveLoginRequest{
+				User: uID, R1: req.GetR1(), R2: req.GetR2(), S: big.NewInt(5).Bytes(), Timestamp: now,
+			},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+			},
+			wantErr: ErrInvalidProof,
+		},
+		{
+			name: "Identity r1",
+			request: &interactor.NonInteractiveLoginRequest{
+				User: uID, R1: []byte{1}, R2: req.GetR2(), S: req.GetS(), Timestamp: now,
+			},
+			setup:   func(*mockAuthRepository) {},
+			wantErr: &InvalidElementError{Name: "r1", Err: ErrIdentityElement},
+		},
+		{
+			name:    "Proof replayed for another user",
+			request: toRequest("UserID2", proof),
+			setup: func(ar *mockAuthRepository) {
+				other, _ := auth.NewUser("UserID2", y1, y2, testSalt, testKDF)
+				ar.On("GetUserRegistration", context.Background(), "UserID2").Return(other, nil)
+			},
+			wantErr: ErrInvalidProof,
+		},
+		{
+			name:    "GetUserRegistration fails",
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), uID).Return(nil, errors.New("GetUserRegistration error"))
+			},
+			wantErr: errors.New("GetUserRegistration error"),
+		},
+		{
+			name:    "StoreSession fails",
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+				ar.On("RecordProof", context.Background(), reqID, now).Return(nil)
+				ar.On("StoreSession", context.Background(), mock.Anything).Return(errors.New("Store Session Error"))
+			},
+			wantErr: errors.New("Store Session Error"),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ar := new(mockAuthRepository)
+			ln := NewLoginNonInteractive(ar)
+			tt.setup(ar)
+			issued, err := ln.Exec(context.Background(), cfg, tt.request, client)
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+				require.Nil(t, issued)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, uID, issued.Session.UserID())
+				require.Equal(t, client, issued.Session.Client())
+				require.NotEmpty(t, issued.Token)
+			}
+			ar.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Channel binding", func(t *testing.T) {
+		t.Parallel()
+		bound, err := NewProveNonInteractive().Exec(cfg, uID, x, now, []byte("channel A"))
+		require.NoError(t, err)
+
+		ar := new(mockAuthRepository)
+		ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+		ar.On("RecordProof", context.Background(), proofID(uID, bound.R1, bound.R2, now), now).Return(nil).Once()
+		ar.On("StoreSession", context.Background(), mock.Anything).Return(nil).Once()
+		ln := NewLoginNonInteractive(ar)
+
+		issued, err := ln.Exec(context.Background(), cfg, toRequest(uID, bound), client.WithChannelBinding([]byte("channel A")))
+		require.NoError(t, err)
+		require.Equal(t, client, issued.Session.Client(), "the session should not keep the channel binding")
+
+		_, err = ln.Exec(context.Background(), cfg, toRequest(uID, bound), client.WithChannelBinding([]byte("channel B")))
+		require.ErrorIs(t, err, ErrInvalidProof, "a proof relayed from another connection should fail")
+		_, err = ln.Exec(context.Background(), cfg, toRequest(uID, bound), client)
+		require.ErrorIs(t, err, ErrInvalidProof, "a proof bound to TLS should fail without it")
+		_, err = ln.Exec(context.Background(), cfg, req, client.WithChannelBinding([]byte("channel A")))
+		require.ErrorIs(t, err, ErrInvalidProof, "an unbound proof should fail over TLS")
+		ar.AssertExpectations(t)
+	})
+
+	t.Run("Proof ID", func(t *testing.T) {
+		t.Parallel()
+		require.Len(t, reqID, 64)
+		require.NotEqual(t, reqID, proofID("UserID2", proof.R1, proof.R2, now))
+		require.NotEqual(t, reqID, proofID(uID, proof.R2, proof.R1, now))
+		require.NotEqual(t, reqID, proofID(uID, proof.R1, proof.R2, now+1))
+	})
+
+	t.Run("Nil config", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewLoginNonInteractive(new(mockAuthRepository)).Exec(context.Background(), nil, req, client)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+)
+
+// LogoutExecuter is an interface that defines the method for revoking a session.
+type LogoutExecuter interface {
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.LogoutRequest) error
+}
+
+// Logout is a type that is responsible for ending a session before it expires.
+type Logout struct {
+	ar repository.AuthRepository
+}
+
+// NewLogout creates a new instance of LogoutExecuter with the provided AuthRepository.
+func NewLogout(ar repository.AuthRepository) LogoutExecuter {
+	return &Logout{ar: ar}
+}
+
+// Exec deletes the session of the user with the requested ID from the repository, so that it is no longer
+// valid and cannot be refreshed.
+// It returns ErrNilConfig, ErrInvalidSessionID if the session ID is not a session token, or the error of
+// hashSessionID or the repository, such as repository.ErrSessionNotFound for an unknown session.
+func (lo Logout) Exec(ctx context.Context, cfg *config.Config, req *interactor.LogoutRequest) error {
+	userID := req.GetUser()
+
+	if cfg == nil {
+		return ErrNilConfig
+	}
+	sessionID, err := hashSessionID(cfg, req.GetSessionId())
+	if err != nil {
+		return err
+	}
+
+	if err = lo.ar.DeleteSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	slog.Info("session revoked", "user", userID, "session", sessionID)
+
+	return nil
+}
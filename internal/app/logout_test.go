@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogout_Exec(t *testing.T) {
+	cfg := &config.Config{SessionKey: testSessionKey}
+	uID := "UserID1"
+	token, sessionID := newTestSession(t)
+	req := &interactor.LogoutRequest{User: uID, SessionId: token}
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		request *interactor.LogoutRequest
+		setup   func(ar *mockAuthRepository)
+		wantErr error
+	}{
+		{
+			name:    "Successful Path",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("DeleteSession", context.Background(), uID, sessionID).Return(nil)
+			},
+		},
+		{
+			name:    "Unknown session",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("DeleteSession", context.Background(), uID, sessionID).Return(repository.ErrSessionNotFound)
+			},
+			wantErr: repository.ErrSessionNotFound,
+		},
+		{
+			name:    "Malformed session ID",
+			cfg:     cfg,
+			request: &interactor.LogoutRequest{User: uID, SessionId: "not-a-token"},
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrInvalidSessionID,
+		},
+		{
+			name:    "Nil config",
+			request: req,
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrNilConfig,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ar := new(mockAuthRepository)
+			tt.setup(ar)
+			err := NewLogout(ar).Exec(context.Background(), tt.cfg, tt.request)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			ar.AssertExpectations(t)
+		})
+	}
+}
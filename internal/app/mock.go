@@ -3,10 +3,8 @@ package app
 import (
 	"context"
 
-	"practical-case-test/internal/domain/auth"
-
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
+	"practical-case-test/internal/domain/auth"
 )
 
 type mockAuthRepository struct {
@@ -26,13 +24,70 @@ func (m *mockAuthRepository) GetAuthenticationChallenge(ctx context.Context, aut
 	return args.Get(0).(*auth.Challenge), args.Error(1)
 }
 
+func (m *mockAuthRepository) ConsumeAuthenticationChallenge(ctx context.Context, authID string) (*auth.Challenge, error) {
+	args := m.Called(ctx, authID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Challenge), args.Error(1)
+}
+
 func (m *mockAuthRepository) StoreSession(ctx context.Context, session auth.Session) error {
 	args := m.Called(ctx, session)
 	return args.Error(0)
 }
 
-func (m *mockAuthRepository) GetSession(_ context.Context, _ string, _ uuid.UUID) (*auth.Session, error) {
-	panic("implement me")
+func (m *mockAuthRepository) GetSession(ctx context.Context, userID string, sessionID auth.SessionHash) (*auth.Session, error) {
+	args := m.Called(ctx, userID, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Session), args.Error(1)
+}
+
+func (m *mockAuthRepository) RefreshSession(ctx context.Context, userID string, sessionID auth.SessionHash,
+	lastSeen int64) (*auth.Session, error) {
+	args := m.Called(ctx, userID, sessionID, lastSeen)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Session), args.Error(1)
+}
+
+func (m *mockAuthRepository) DeleteSession(ctx context.Context, userID string, sessionID auth.SessionHash) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *mockAuthRepository) ListSessions(ctx context.Context, userID string) ([]auth.Session, error) {
+	args := m.Called(ctx, userID)
+	sessions, _ := args.Get(0).([]auth.Session)
+	return sessions, args.Error(1)
+}
+
+func (m *mockAuthRepository) RevokeAllSessions(ctx context.Context, userID string, keep auth.SessionHash) (int, error) {
+	args := m.Called(ctx, userID, keep)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockAuthRepository) CountChallenges(ctx context.Context, issuedSince int64) (int, error) {
+	args := m.Called(ctx, issuedSince)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockAuthRepository) RecordProof(ctx context.Context, proofID string, timestamp int64) error {
+	args := m.Called(ctx, proofID, timestamp)
+	return args.Error(0)
+}
+
+func (m *mockAuthRepository) Flush(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *mockAuthRepository) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
 }
 
 func (m *mockAuthRepository) GetUserRegistration(ctx context.Context, userID string) (*auth.User, error) {
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"log/slog"
+	"math/big"
+
+	"practical-case-test/config"
+)
+
+// NonInteractiveProof represents a Fiat-Shamir proof of knowledge of x: the commitments
+// r1 and r2, the response s and the Unix timestamp the challenge was bound to.
+type NonInteractiveProof struct {
+	R1, R2, S *big.Int
+	Timestamp int64
+}
+
+// ProveNonInteractiveExecuter is an interface that defines the `Exec` method for producing
+// a non-interactive proof for a user.
+//
+// The `Exec` method takes a `cfg` configuration object, the user ID, the secret `x`, and the
+// timestamp and the TLS channel binding to bind the proof to, and returns a NonInteractiveProof and an error.
+type ProveNonInteractiveExecuter interface {
+	Exec(cfg *config.Config, userID string, x *big.Int, timestamp int64, channelBinding []byte) (
+		*NonInteractiveProof, error)
+}
+
+// ProveNonInteractive represents a type that produces Fiat-Shamir proofs, replacing the
+// challenge of the verifier by a hash of the proof transcript.
+type ProveNonInteractive struct{}
+
+// NewProveNonInteractive returns a new instance of ProveNonInteractiveExecuter.
+func NewProveNonInteractive() ProveNonInteractiveExecuter {
+	return &ProveNonInteractive{}
+}
+
+// Exec computes y1 and y2 from x, generates a random commitment (r1, r2, k), derives the
+// challenge c from the transcript and channelBinding with fiatShamirChallenge and answers it with
+// s = (k - c * x) mod q.
+// It returns nil and ErrConfigNil if the configuration is nil, or nil and the error of
+// any of the calculations.
+func (pn ProveNonInteractive) Exec(cfg *config.Config, userID string, x *big.Int, timestamp int64,
+	channelBinding []byte) (
+	*NonInteractiveProof,
+	error,
+) {
+	if cfg == nil {
+		return nil, ErrConfigNil
+	}
+	y1, y2, err := calculateYs(cfg, x)
+	if err != nil {
+		return nil, err
+	}
+	r1, r2, k, err := calculateCommitment(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c, err := fiatShamirChallenge(cfg, userID, y1, y2, r1, r2, timestamp, channelBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("derived Fiat-Shamir challenge", "user", userID, "c", c, "timestamp", timestamp)
+
+	s, err := calculateS(cfg, c, x, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NonInteractiveProof{
+		R1:        r1,
+		R2:        r2,
+		S:         s,
+		Timestamp: timestamp,
+	}, nil
+}
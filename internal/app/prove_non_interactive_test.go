@@ -0,0 +1,91 @@
+package app
+
+import (
+	"math/big"
+	"testing"
+
+	"practical-case-test/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProveNonInteractive_Exec(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		wantErr bool
+	}{
+		{
+			name:    "Valid case",
+			cfg:     cfg,
+			wantErr: false,
+		},
+		{
+			name:    "Invalid case: no Config",
+			cfg:     nil,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid case: zero q",
+			cfg:     &config.Config{Q: big.NewInt(0)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			proof, err := NewProveNonInteractive().Exec(tt.cfg, "user", big.NewInt(42), 1700000000, nil)
+			if tt.wantErr {
+				require.Error(t, err, "Exec() should return an error.")
+				return
+			}
+			require.NoError(t, err, "Exec() should not return an error.")
+			require.Equal(t, int64(1700000000), proof.Timestamp)
+			require.NotNil(t, proof.R1)
+			require.NotNil(t, proof.R2)
+			require.NotNil(t, proof.S)
+		})
+	}
+}
+
+func Test_fiatShamirChallenge(t *testing.T) {
+	cfg := &config.Config{G: big.NewInt(4), H: big.NewInt(9), P: big.NewInt(23), Q: big.NewInt(11)}
+	y1, y2, r1, r2 := big.NewInt(18), big.NewInt(16), big.NewInt(12), big.NewInt(8)
+
+	c, err := fiatShamirChallenge(cfg, "user", y1, y2, r1, r2, 1700000000, nil)
+	require.NoError(t, err)
+	require.Positive(t, c.Sign(), "challenge should not be zero")
+	require.Negative(t, c.Cmp(cfg.Q), "challenge should be below q")
+
+	again, err := fiatShamirChallenge(cfg, "user", y1, y2, r1, r2, 1700000000, nil)
+	require.NoError(t, err)
+	require.Equal(t, c, again, "challenge should be deterministic")
+
+	_, err = fiatShamirChallenge(cfg, "user", y1, y2, nil, r2, 1700000000, nil)
+	require.Error(t, err, "missing commitment should fail")
+
+	// The toy group only has ten possible challenges, so compare full digests on a real group.
+	cfg, err = config.LoadConfig()
+	require.NoError(t, err)
+	y1, y2, err = calculateYs(cfg, big.NewInt(42))
+	require.NoError(t, err)
+	r1, r2, _, err = calculateCommitment(cfg)
+	require.NoError(t, err)
+	c, err = fiatShamirChallenge(cfg, "user", y1, y2, r1, r2, 1700000000, nil)
+	require.NoError(t, err)
+	for name, other := range map[string]func() (*big.Int, error){
+		"user":      func() (*big.Int, error) { return fiatShamirChallenge(cfg, "other", y1, y2, r1, r2, 1700000000, nil) },
+		"timestamp": func() (*big.Int, error) { return fiatShamirChallenge(cfg, "user", y1, y2, r1, r2, 1700000001, nil) },
+		"swapped r": func() (*big.Int, error) { return fiatShamirChallenge(cfg, "user", y1, y2, r2, r1, 1700000000, nil) },
+		"channel binding": func() (*big.Int, error) {
+			return fiatShamirChallenge(cfg, "user", y1, y2, r1, r2, 1700000000, []byte("channel"))
+		},
+	} {
+		got, err := other()
+		require.NoError(t, err)
+		require.NotEqual(t, c, got, "challenge should depend on %s", name)
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+)
+
+// ErrSessionExpired is an error indicating that a session has outlived its absolute or idle TTL.
+var ErrSessionExpired = errors.New("session expired")
+
+// RefreshSessionExecuter is an interface that defines the method for extending an active session.
+type RefreshSessionExecuter interface {
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.RefreshSessionRequest) (*SessionStatus, error)
+}
+
+// RefreshSession is a type that is responsible for keeping an active session from running into its idle TTL.
+type RefreshSession struct {
+	ar repository.AuthRepository
+}
+
+// NewRefreshSession creates a new instance of RefreshSessionExecuter with the provided AuthRepository.
+func NewRefreshSession(ar repository.AuthRepository) RefreshSessionExecuter {
+	return &RefreshSession{ar: ar}
+}
+
+// Exec marks the session of the user with the requested ID as seen now, which moves its idle expiry
+// cfg.SessionIdleTTL into the future but never beyond cfg.SessionTTL after the login, and returns its
+// new status. A session that has already expired is deleted instead and ErrSessionExpired is returned.
+// It returns ErrNilConfig, ErrInvalidSessionID if the session ID is not a session token, or the error of
+// hashSessionID or the repository, such as repository.ErrSessionNotFound for an unknown session.
+func (rs RefreshSession) Exec(ctx context.Context, cfg *config.Config,
+	req *interactor.RefreshSessionRequest) (*SessionStatus, error) {
+	userID := req.GetUser()
+
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	sessionID, err := hashSessionID(cfg, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := rs.ar.GetSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.IsExpired(now, cfg.SessionTTL, cfg.SessionIdleTTL) {
+		if err = rs.ar.DeleteSession(ctx, userID, sessionID); err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+			return nil, err
+		}
+		slog.Info("expired session dropped on refresh", "user", userID, "session", sessionID)
+		return nil, ErrSessionExpired
+	}
+
+	session, err = rs.ar.RefreshSession(ctx, userID, sessionID, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("session refreshed", "user", userID, "session", sessionID)
+
+	return &SessionStatus{
+		Valid:     true,
+		Session:   session,
+		ExpiresAt: session.ExpiresAt(cfg.SessionTTL, cfg.SessionIdleTTL).Unix(),
+	}, nil
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshSession_Exec(t *testing.T) {
+	cfg := &config.Config{SessionKey: testSessionKey, SessionTTL: time.Hour, SessionIdleTTL: 30 * time.Minute}
+
+	uID := "UserID1"
+	token, sessionID := newTestSession(t)
+	now := time.Now()
+	active, err := auth.NewSession(sessionID, uID, now.Add(-10*time.Minute).Unix())
+	require.NoError(t, err)
+	refreshed := active.Refreshed(now.Unix())
+	lateRefresh := active.Refreshed(now.Add(-10 * time.Minute).Unix())
+	nearEnd, err := auth.NewSession(sessionID, uID, now.Add(-50*time.Minute).Unix())
+	require.NoError(t, err)
+	nearEndSeen := nearEnd.Refreshed(now.Add(-5 * time.Minute).Unix())
+	nearEndRefreshed := nearEnd.Refreshed(now.Unix())
+	idle, err := auth.NewSession(sessionID, uID, now.Add(-40*time.Minute).Unix())
+	require.NoError(t, err)
+
+	req := &interactor.RefreshSessionRequest{User: uID, SessionId: token}
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		request *interactor.RefreshSessionRequest
+		setup   func(ar *mockAuthRepository)
+		want    *SessionStatus
+		wantErr error
+	}{
+		{
+			name:    "Active session",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(&lateRefresh, nil)
+				ar.On("RefreshSession", context.Background(), uID, sessionID, mock.AnythingOfType("int64")).Return(&refreshed, nil)
+			},
+			want: &SessionStatus{Valid: true, Session: &refreshed, ExpiresAt: now.Add(30 * time.Minute).Unix()},
+		},
+		{
+			name:    "Refresh capped by the absolute lifetime",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(&nearEndSeen, nil)
+				ar.On("RefreshSession", context.Background(), uID, sessionID, mock.AnythingOfType("int64")).Return(&nearEndRefreshed, nil)
+			},
+			want: &SessionStatus{Valid: true, Session: &nearEndRefreshed, ExpiresAt: now.Add(10 * time.Minute).Unix()},
+		},
+		{
+			name:    "Idle session is dropped",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(idle, nil)
+				ar.On("DeleteSession", context.Background(), uID, sessionID).Return(nil)
+			},
+			wantErr: ErrSessionExpired,
+		},
+		{
+			name:    "Idle session already purged",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(idle, nil)
+				ar.On("DeleteSession", context.Background(), uID, sessionID).Return(repository.ErrSessionNotFound)
+			},
+			wantErr: ErrSessionExpired,
+		},
+		{
+			name:    "Unknown session",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(nil, repository.ErrSessionNotFound)
+			},
+			wantErr: repository.ErrSessionNotFound,
+		},
+		{
+			name:    "Session revoked during refresh",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(active, nil)
+				ar.On("RefreshSession", context.Background(), uID, sessionID, mock.AnythingOfType("int64")).
+					Return(nil, repository.ErrSessionNotFound)
+			},
+			wantErr: repository.ErrSessionNotFound,
+		},
+		{
+			name:    "Malformed session ID",
+			cfg:     cfg,
+			request: &interactor.RefreshSessionRequest{User: uID, SessionId: "not-a-token"},
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrInvalidSessionID,
+		},
+		{
+			name:    "Nil config",
+			request: req,
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrNilConfig,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ar := new(mockAuthRepository)
+			tt.setup(ar)
+			got, err := NewRefreshSession(ar).Exec(context.Background(), tt.cfg, tt.request)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+			ar.AssertExpectations(t)
+		})
+	}
+}
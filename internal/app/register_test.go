@@ -37,24 +37,37 @@ func TestRegister_Exec(t *testing.T) {
 			expectedY2:  nil,
 			expectedErr: ErrZeroQ,
 		},
+		{
+			name: "Zero P Config Test",
+			cfg: &config.Config{
+				P: new(big.Int),
+				Q: big.NewInt(11),
+			},
+			password:    big.NewInt(10),
+			expectedY1:  nil,
+			expectedY2:  nil,
+			expectedErr: ErrZeroP,
+		},
 		{
 			name: "Positive Number Test",
 			cfg: &config.Config{
-				Q: big.NewInt(10),
-				G: big.NewInt(2),
-				H: big.NewInt(2),
+				P: big.NewInt(23),
+				Q: big.NewInt(11),
+				G: big.NewInt(4),
+				H: big.NewInt(9),
 			},
 			password:    big.NewInt(10),
-			expectedY1:  big.NewInt(4),
-			expectedY2:  big.NewInt(4),
+			expectedY1:  big.NewInt(6),
+			expectedY2:  big.NewInt(18),
 			expectedErr: nil,
 		},
 		{
 			name: "Zero Number Test",
 			cfg: &config.Config{
-				Q: big.NewInt(10),
-				G: big.NewInt(2),
-				H: big.NewInt(2),
+				P: big.NewInt(23),
+				Q: big.NewInt(11),
+				G: big.NewInt(4),
+				H: big.NewInt(9),
 			},
 			password:    big.NewInt(0),
 			expectedY1:  big.NewInt(1),
@@ -64,13 +77,14 @@ func TestRegister_Exec(t *testing.T) {
 		{
 			name: "Negative Number Test",
 			cfg: &config.Config{
-				Q: big.NewInt(10),
-				G: big.NewInt(2),
-				H: big.NewInt(2),
+				P: big.NewInt(23),
+				Q: big.NewInt(11),
+				G: big.NewInt(4),
+				H: big.NewInt(9),
 			},
 			password:    big.NewInt(-10),
-			expectedY1:  big.NewInt(1),
-			expectedY2:  big.NewInt(1),
+			expectedY1:  big.NewInt(4),
+			expectedY2:  big.NewInt(9),
 			expectedErr: nil,
 		},
 	}
@@ -3,16 +3,19 @@ package app
 import (
 	"context"
 	"log/slog"
+	"math/big"
 
+	"practical-case-test/config"
 	"practical-case-test/internal/domain/auth"
 	interactor "practical-case-test/internal/interactor/proto"
 	"practical-case-test/internal/repository"
 )
 
 // RegisterUserExecuter is an interface that defines the method for executing user registration.
-// Exec takes a context and a RegisterRequest and returns an error if any occurred during the execution.
+// Exec takes a context, a RegisterRequest and the client it came from and returns an error if any occurred
+// during the execution.
 type RegisterUserExecuter interface {
-	Exec(ctx context.Context, req *interactor.RegisterRequest) error
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.RegisterRequest, client auth.ClientInfo) error
 }
 
 // RegisterUser is a type that is responsible for registering a new user.
@@ -30,25 +33,46 @@ func NewRegisterUser(ar repository.AuthRepository) RegisterUserExecuter {
 
 // Exec executes the register user use case.
 // It takes in a context and a RegisterRequest object and returns an error.
-// The function extracts user, y1, and y2 values from the request.
+// The function extracts user, y1, y2, the salt and the Argon2id cost parameters from the request.
 // It then logs the registration request.
 // The function creates a new User object using auth.NewUser and the extracted values.
-// If the user object is invalid, it returns an error.
+// If the user object is invalid, it returns an error, and if its Argon2id cost exceeds the limits of cfg it
+// returns ErrKDFCostTooHigh, since every prover logging in as the user has to afford that cost.
+// A user listed in cfg.AdminUsers may only register from a client that presented a verified client
+// certificate issued to that user, see auth.ClientInfo.CertificateName; otherwise it returns
+// ErrAdminCertificateRequired, so that nobody else can claim the name of an admin.
+// It then checks that y1 and y2 are non-identity elements of the configured group and returns an
+// *InvalidElementError otherwise, so no degenerate or small-subgroup values get registered.
 // The function stores the user registration using the AuthRepository.
 // If there is an error storing the registration, it returns the error.
 // Finally, it returns nil if no errors occurred.
-func (ru RegisterUser) Exec(ctx context.Context, req *interactor.RegisterRequest) error {
+func (ru RegisterUser) Exec(ctx context.Context, cfg *config.Config, req *interactor.RegisterRequest,
+	client auth.ClientInfo) error {
 	user := req.GetUser()
-	y1 := req.GetY1()
-	y2 := req.GetY2()
+	y1 := new(big.Int).SetBytes(req.GetY1Bytes())
+	y2 := new(big.Int).SetBytes(req.GetY2Bytes())
+	salt := req.GetSalt()
+	kdf := auth.KDFParams{Time: req.GetArgon2Time(), MemoryKiB: req.GetArgon2MemoryKib(), Threads: req.GetArgon2Threads()}
 
 	slog.Info("received registration request\n", "user", user, "y1", y1, "y2", y2)
 
-	newUser, err := auth.NewUser(user, y1, y2)
+	newUser, err := auth.NewUser(user, y1, y2, salt, kdf)
 	if err != nil {
 		return err
 	}
 
+	if err = checkKDFCost(cfg, kdf); err != nil {
+		return err
+	}
+
+	if cfg.IsAdmin(user) && client.CertificateName() != user {
+		return ErrAdminCertificateRequired
+	}
+
+	if err = validateElements(cfg, namedValue{"y1", y1}, namedValue{"y2", y2}); err != nil {
+		return err
+	}
+
 	err = ru.ar.StoreUserRegistration(ctx, *newUser)
 	if err != nil {
 		return err
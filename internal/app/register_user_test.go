@@ -1,51 +1,122 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"math/big"
+	"strings"
 	"testing"
 
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
 	data "practical-case-test/internal/interactor/proto"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 )
 
 // mockAuthRepository was defined in the previous message
 
 func TestRegisterUser_Exec(t *testing.T) {
+	salt := bytes.Repeat([]byte{0x5a}, auth.MinSaltLength)
+	cfg := &config.Config{G: big.NewInt(4), H: big.NewInt(9), P: big.NewInt(23), Q: big.NewInt(11),
+		AdminUsers: []string{"admin"}, Argon2MaxTime: 4, Argon2MaxMemoryKiB: 1024, Argon2MaxThreads: 2}
+	y1, y2 := big.NewInt(18).Bytes(), big.NewInt(16).Bytes()
+	// withCost sets the Argon2id cost parameters of testKDF on req.
+	withCost := func(req *data.RegisterRequest) *data.RegisterRequest {
+		req.Argon2Time, req.Argon2MemoryKib, req.Argon2Threads = testKDF.Time, testKDF.MemoryKiB, testKDF.Threads
+		return req
+	}
+
 	testCases := []struct {
 		name          string
 		req           *data.RegisterRequest
+		client        auth.ClientInfo
 		mockStoreErr  error
 		expectedError string
 	}{
 		{
 			name:         "Register user successful case",
-			req:          &data.RegisterRequest{User: "testUser", Y1: 0, Y2: 0},
+			req:          withCost(&data.RegisterRequest{User: "testUser", Y1Bytes: y1, Y2Bytes: y2, Salt: salt}),
 			mockStoreErr: nil,
 		},
 		{
 			name:          "StoreUserRegistration returns error",
-			req:           &data.RegisterRequest{User: "testUser", Y1: 0, Y2: 0},
+			req:           withCost(&data.RegisterRequest{User: "testUser", Y1Bytes: y1, Y2Bytes: y2, Salt: salt}),
 			mockStoreErr:  errors.New("store user registration error"),
 			expectedError: "store user registration error",
 		},
 		{
 			name:          "Invalid user - Empty username",
-			req:           &data.RegisterRequest{User: "", Y1: 0, Y2: 0},
+			req:           withCost(&data.RegisterRequest{User: "", Y1Bytes: y1, Y2Bytes: y2, Salt: salt}),
 			expectedError: "invalid user",
 		},
 		{
-			name:          "Invalid user - Negative Y1",
-			req:           &data.RegisterRequest{User: "testUser", Y1: -1, Y2: 0},
+			name:          "Invalid user - Short salt",
+			req:           withCost(&data.RegisterRequest{User: "testUser", Y1Bytes: y1, Y2Bytes: y2, Salt: salt[:auth.MinSaltLength-1]}),
 			expectedError: "invalid user",
 		},
 		{
-			name:          "Invalid user - Negative Y2",
-			req:           &data.RegisterRequest{User: "testUser", Y1: 0, Y2: -1},
+			name:          "Invalid user - Missing Argon2id cost",
+			req:           &data.RegisterRequest{User: "testUser", Y1Bytes: y1, Y2Bytes: y2, Salt: salt},
 			expectedError: "invalid user",
 		},
+		{
+			name: "Argon2id memory above the limit",
+			req: &data.RegisterRequest{User: "testUser", Y1Bytes: y1, Y2Bytes: y2, Salt: salt,
+				Argon2Time: 1, Argon2MemoryKib: 1 << 31, Argon2Threads: 1},
+			expectedError: ErrKDFCostTooHigh.Error(),
+		},
+		{
+			name: "Argon2id time above the limit",
+			req: &data.RegisterRequest{User: "testUser", Y1Bytes: y1, Y2Bytes: y2, Salt: salt,
+				Argon2Time: 5, Argon2MemoryKib: 64, Argon2Threads: 1},
+			expectedError: ErrKDFCostTooHigh.Error(),
+		},
+		{
+			name:          "Invalid y1 - Zero",
+			req:           withCost(&data.RegisterRequest{User: "testUser", Y1Bytes: nil, Y2Bytes: y2, Salt: salt}),
+			expectedError: "invalid y1: invalid group element",
+		},
+		{
+			name:          "Invalid y2 - Identity",
+			req:           withCost(&data.RegisterRequest{User: "testUser", Y1Bytes: y1, Y2Bytes: []byte{1}, Salt: salt}),
+			expectedError: "invalid y2: element is the identity",
+		},
+		{
+			name:          "Invalid y1 - Not below p",
+			req:           withCost(&data.RegisterRequest{User: "testUser", Y1Bytes: big.NewInt(41).Bytes(), Y2Bytes: y2, Salt: salt}),
+			expectedError: "invalid y1: invalid group element",
+		},
+		{
+			name:          "Invalid y2 - Outside the order-q subgroup",
+			req:           withCost(&data.RegisterRequest{User: "testUser", Y1Bytes: y1, Y2Bytes: big.NewInt(5).Bytes(), Salt: salt}),
+			expectedError: "invalid y2: invalid group element",
+		},
+		{
+			name:   "Admin with a client certificate issued to them",
+			req:    withCost(&data.RegisterRequest{User: "admin", Y1Bytes: y1, Y2Bytes: y2, Salt: salt}),
+			client: auth.ClientInfo{}.WithCertificateName("admin"),
+		},
+		{
+			name:          "Admin without a client certificate",
+			req:           withCost(&data.RegisterRequest{User: "admin", Y1Bytes: y1, Y2Bytes: y2, Salt: salt}),
+			expectedError: ErrAdminCertificateRequired.Error(),
+		},
+		{
+			name:          "Admin with the client certificate of another user",
+			req:           withCost(&data.RegisterRequest{User: "admin", Y1Bytes: y1, Y2Bytes: y2, Salt: salt}),
+			client:        auth.ClientInfo{}.WithCertificateName("testUser"),
+			expectedError: ErrAdminCertificateRequired.Error(),
+		},
+		{
+			name:          "Invalid y1 - Large value",
+			req:           withCost(&data.RegisterRequest{User: "testUser", Y1Bytes: bytes.Repeat([]byte{0xff}, 256), Y2Bytes: y2, Salt: salt}),
+			expectedError: "invalid y1: invalid group element",
+		},
 	}
 
 	for _, tt := range testCases {
@@ -54,11 +125,13 @@ func TestRegisterUser_Exec(t *testing.T) {
 			ar := new(mockAuthRepository)
 			registrar := NewRegisterUser(ar)
 
-			if tt.expectedError != "invalid user" {
+			if tt.expectedError == "" ||
+				!strings.HasPrefix(tt.expectedError, "invalid ") && tt.expectedError != ErrAdminCertificateRequired.Error() &&
+					tt.expectedError != ErrKDFCostTooHigh.Error() {
 				ar.On("StoreUserRegistration", mock.Anything, mock.Anything).Return(tt.mockStoreErr)
 			}
 
-			err := registrar.Exec(context.Background(), tt.req)
+			err := registrar.Exec(context.Background(), cfg, tt.req, tt.client)
 
 			if tt.expectedError != "" {
 				require.Error(t, err, "Expected an error but got none")
@@ -70,3 +143,27 @@ func TestRegisterUser_Exec(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterUser_ExecLegacyEncoding(t *testing.T) {
+	// A registration of a v1 client, which sent y1 and y2 as int64 fields 2 and 3.
+	var legacy []byte
+	legacy = protowire.AppendTag(legacy, 1, protowire.BytesType)
+	legacy = protowire.AppendString(legacy, "testUser")
+	legacy = protowire.AppendTag(legacy, 2, protowire.VarintType)
+	legacy = protowire.AppendVarint(legacy, 18)
+	legacy = protowire.AppendTag(legacy, 3, protowire.VarintType)
+	legacy = protowire.AppendVarint(legacy, 16)
+
+	req := new(data.RegisterRequest)
+	require.NoError(t, proto.Unmarshal(legacy, req))
+	require.Empty(t, req.GetY1Bytes(), "the reserved int64 fields should not be read as y1")
+	require.Empty(t, req.GetY2Bytes(), "the reserved int64 fields should not be read as y2")
+
+	cfg := &config.Config{G: big.NewInt(4), H: big.NewInt(9), P: big.NewInt(23), Q: big.NewInt(11),
+		Argon2MaxTime: 4, Argon2MaxMemoryKiB: 1024, Argon2MaxThreads: 2}
+	req.Salt = bytes.Repeat([]byte{0x5a}, auth.MinSaltLength)
+	req.Argon2Time, req.Argon2MemoryKib, req.Argon2Threads = testKDF.Time, testKDF.MemoryKiB, testKDF.Threads
+	err := NewRegisterUser(new(mockAuthRepository)).Exec(context.Background(), cfg, req, auth.ClientInfo{})
+	var invalid *InvalidElementError
+	require.ErrorAs(t, err, &invalid, "a legacy registration should be rejected")
+}
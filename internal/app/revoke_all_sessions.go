@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+)
+
+// RevokeAllSessionsExecuter is an interface that defines the method for revoking every session of a user.
+type RevokeAllSessionsExecuter interface {
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.RevokeAllSessionsRequest, client auth.ClientInfo) (int,
+		error)
+}
+
+// RevokeAllSessions is a type that is responsible for logging a user out everywhere, for instance after a
+// device was lost.
+type RevokeAllSessions struct {
+	ar repository.AuthRepository
+}
+
+// NewRevokeAllSessions creates a new instance of RevokeAllSessionsExecuter with the provided AuthRepository.
+func NewRevokeAllSessions(ar repository.AuthRepository) RevokeAllSessionsExecuter {
+	return &RevokeAllSessions{ar: ar}
+}
+
+// Exec authorizes the request of client with authorizeSessionAccess and deletes every session of the target user,
+// except for the session the request is authenticated with if KeepCurrent is set and it belongs to the
+// target. It returns how many sessions were revoked.
+// It returns the errors of authorizeSessionAccess or of the repository.
+func (ra RevokeAllSessions) Exec(ctx context.Context, cfg *config.Config, req *interactor.RevokeAllSessionsRequest,
+	client auth.ClientInfo) (int, error) {
+	target, current, err := authorizeSessionAccess(ctx, ra.ar, cfg, req.GetUser(), req.GetSessionId(), req.GetTargetUser(),
+		client)
+	if err != nil {
+		return 0, err
+	}
+
+	var keep auth.SessionHash
+	if req.GetKeepCurrent() && target == req.GetUser() {
+		keep = current
+	}
+
+	revoked, err := ra.ar.RevokeAllSessions(ctx, target, keep)
+	if err != nil {
+		return 0, err
+	}
+
+	slog.Info("sessions revoked", "user", req.GetUser(), "target", target, "count", revoked)
+
+	return revoked, nil
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeAllSessions_Exec(t *testing.T) {
+	cfg := &config.Config{
+		SessionKey:     testSessionKey,
+		SessionTTL:     time.Hour,
+		SessionIdleTTL: 30 * time.Minute,
+		AdminUsers:     []string{"admin"},
+	}
+
+	uID := "UserID1"
+	token, sessionID := newTestSession(t)
+	now := time.Now()
+	current, err := auth.NewSession(sessionID, uID, now.Add(-10*time.Minute).Unix())
+	require.NoError(t, err)
+	adminSession, err := auth.NewSession(sessionID, "admin", now.Unix())
+	require.NoError(t, err)
+	adminClient := auth.ClientInfo{}.WithCertificateName("admin")
+	*adminSession = adminSession.WithClient(adminClient)
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		request *interactor.RevokeAllSessionsRequest
+		client  auth.ClientInfo
+		setup   func(ar *mockAuthRepository)
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "Revoke all own sessions",
+			cfg:     cfg,
+			request: &interactor.RevokeAllSessionsRequest{User: uID, SessionId: token},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(current, nil)
+				ar.On("RevokeAllSessions", context.Background(), uID, auth.SessionHash{}).Return(3, nil)
+			},
+			want: 3,
+		},
+		{
+			name:    "Keep the current session",
+			cfg:     cfg,
+			request: &interactor.RevokeAllSessionsRequest{User: uID, SessionId: token, KeepCurrent: true},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(current, nil)
+				ar.On("RevokeAllSessions", context.Background(), uID, sessionID).Return(2, nil)
+			},
+			want: 2,
+		},
+		{
+			name: "Admin revokes another user",
+			cfg:  cfg,
+			request: &interactor.RevokeAllSessionsRequest{
+				User: "admin", SessionId: token, TargetUser: uID, KeepCurrent: true,
+			},
+			client: adminClient,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), "admin", sessionID).Return(adminSession, nil)
+				ar.On("RevokeAllSessions", context.Background(), uID, auth.SessionHash{}).Return(1, nil)
+			},
+			want: 1,
+		},
+		{
+			name:    "Admin session replayed without a client certificate",
+			cfg:     cfg,
+			request: &interactor.RevokeAllSessionsRequest{User: "admin", SessionId: token, TargetUser: uID},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), "admin", sessionID).Return(adminSession, nil)
+			},
+			wantErr: ErrAdminCertificateRequired,
+		},
+		{
+			name:    "Other user without admin rights",
+			cfg:     cfg,
+			request: &interactor.RevokeAllSessionsRequest{User: uID, SessionId: token, TargetUser: "admin"},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(current, nil)
+			},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name:    "Unknown caller session",
+			cfg:     cfg,
+			request: &interactor.RevokeAllSessionsRequest{User: uID, SessionId: token},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(nil, repository.ErrSessionNotFound)
+			},
+			wantErr: ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ar := new(mockAuthRepository)
+			tt.setup(ar)
+			got, err := NewRevokeAllSessions(ar).Exec(context.Background(), tt.cfg, tt.request, tt.client)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+			ar.AssertExpectations(t)
+		})
+	}
+}
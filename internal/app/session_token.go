@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	"practical-case-test/internal/repository"
+	"practical-case-test/pkg/sessionjwt"
+)
+
+// IssuedSession is a session opened by a login together with its bearer token. The token is handed to the
+// client once and never stored; the session is stored under the hash of the token, which is its ID.
+// SignedToken is the signed session token of the login, see package sessionjwt, and only set if
+// cfg.SessionJWT is.
+type IssuedSession struct {
+	Session     *auth.Session
+	Token       string
+	SignedToken string
+}
+
+// issueSession draws a session token for the user, stores a session logged in at loginTimestamp from client
+// under the hash of the token with cfg.SessionKey, and returns the session together with the token. With
+// cfg.SessionJWT set, it also signs a token for the session with cfg.SessionJWTKey that expires when the
+// session would without being refreshed.
+// It returns auth.ErrInvalidSessionKey if cfg has no SessionKey, sessionjwt.ErrInvalidKey if it has no valid
+// SessionJWTKey while SessionJWT is set, or the error of the repository.
+func issueSession(ctx context.Context, ar repository.AuthRepository, cfg *config.Config, userID string,
+	loginTimestamp int64, client auth.ClientInfo) (*IssuedSession, error) {
+	token, err := auth.NewSessionToken(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := auth.HashSessionToken(cfg.SessionKey, token)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := auth.NewSession(hash, userID, loginTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	// The channel binding only matters while the proof is checked, the session does not keep it.
+	*session = session.WithClient(client.WithChannelBinding(nil))
+
+	issued := &IssuedSession{Session: session, Token: token}
+	if cfg.SessionJWT {
+		issued.SignedToken, err = sessionjwt.Sign(cfg.SessionJWTKey, sessionjwt.Claims{
+			Subject:   userID,
+			SessionID: hash.String(),
+			IssuedAt:  loginTimestamp,
+			ExpiresAt: session.ExpiresAt(cfg.SessionTTL, cfg.SessionIdleTTL).Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = ar.StoreSession(ctx, *session); err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+// hashSessionID hashes the session ID sent by a client, which is the token of the session, with
+// cfg.SessionKey into the ID the session is stored under. It returns ErrInvalidSessionID if it is not a
+// session token, or auth.ErrInvalidSessionKey if cfg has no SessionKey.
+func hashSessionID(cfg *config.Config, raw string) (auth.SessionHash, error) {
+	hash, err := auth.HashSessionToken(cfg.SessionKey, raw)
+	if errors.Is(err, auth.ErrInvalidSessionToken) {
+		return auth.SessionHash{}, ErrInvalidSessionID
+	}
+	return hash, err
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	"practical-case-test/pkg/sessionjwt"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testSessionKey is the key the session tokens of the tests are hashed with.
+var testSessionKey = bytes.Repeat([]byte{0x5e}, 32)
+
+// newTestSession draws a session token and returns it together with its hash under testSessionKey.
+func newTestSession(t *testing.T) (string, auth.SessionHash) {
+	t.Helper()
+	token, err := auth.NewSessionToken(rand.Reader)
+	require.NoError(t, err)
+	hash, err := auth.HashSessionToken(testSessionKey, token)
+	require.NoError(t, err)
+	return token, hash
+}
+
+// newTestSessionHash returns the hash of a fresh session token under testSessionKey.
+func newTestSessionHash(t *testing.T) auth.SessionHash {
+	t.Helper()
+	_, hash := newTestSession(t)
+	return hash
+}
+
+func Test_issueSession(t *testing.T) {
+	cfg := &config.Config{SessionKey: testSessionKey}
+	client := auth.NewClientInfo("192.0.2.1:54321", "zkp-prover", "phone")
+
+	t.Run("Stores the session under the hash of its token", func(t *testing.T) {
+		t.Parallel()
+		var stored auth.Session
+		ar := new(mockAuthRepository)
+		ar.On("StoreSession", context.Background(), mock.AnythingOfType("auth.Session")).
+			Run(func(args mock.Arguments) { stored, _ = args.Get(1).(auth.Session) }).
+			Return(nil)
+
+		issued, err := issueSession(context.Background(), ar, cfg, "UserID1", 1234, client)
+		require.NoError(t, err)
+		hash, err := hashSessionID(cfg, issued.Token)
+		require.NoError(t, err)
+		require.Equal(t, hash, issued.Session.ID())
+		require.Equal(t, *issued.Session, stored)
+		require.Equal(t, "UserID1", stored.UserID())
+		require.Equal(t, int64(1234), stored.LoginTimestamp())
+		require.Equal(t, client, stored.Client())
+		require.NotContains(t, stored.ID().String(), issued.Token)
+		require.Empty(t, issued.SignedToken, "no signed token should be issued without SessionJWT")
+		ar.AssertExpectations(t)
+	})
+
+	t.Run("Signs a token for the session", func(t *testing.T) {
+		t.Parallel()
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		jwtCfg := &config.Config{SessionKey: testSessionKey, SessionTTL: time.Hour, SessionIdleTTL: 10 * time.Minute,
+			SessionJWT: true, SessionJWTKey: private}
+		ar := new(mockAuthRepository)
+		ar.On("StoreSession", context.Background(), mock.Anything).Return(nil)
+
+		loginTimestamp := time.Now().Unix()
+		issued, err := issueSession(context.Background(), ar, jwtCfg, "UserID1", loginTimestamp, client)
+		require.NoError(t, err)
+		keys, err := sessionjwt.NewKeySet(public)
+		require.NoError(t, err)
+		claims, err := sessionjwt.Verify(issued.SignedToken, keys, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, sessionjwt.Claims{
+			Subject:   "UserID1",
+			SessionID: issued.Session.ID().String(),
+			IssuedAt:  loginTimestamp,
+			ExpiresAt: loginTimestamp + int64((10 * time.Minute).Seconds()),
+		}, *claims)
+	})
+
+	t.Run("No signing key", func(t *testing.T) {
+		t.Parallel()
+		ar := new(mockAuthRepository)
+		_, err := issueSession(context.Background(), ar, &config.Config{SessionKey: testSessionKey, SessionJWT: true},
+			"UserID1", 1234, client)
+		require.ErrorIs(t, err, sessionjwt.ErrInvalidKey)
+		ar.AssertNotCalled(t, "StoreSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fresh token for every session", func(t *testing.T) {
+		t.Parallel()
+		ar := new(mockAuthRepository)
+		ar.On("StoreSession", context.Background(), mock.Anything).Return(nil)
+		first, err := issueSession(context.Background(), ar, cfg, "UserID1", 1234, client)
+		require.NoError(t, err)
+		second, err := issueSession(context.Background(), ar, cfg, "UserID1", 1234, client)
+		require.NoError(t, err)
+		require.NotEqual(t, first.Token, second.Token)
+		require.NotEqual(t, first.Session.ID(), second.Session.ID())
+	})
+
+	t.Run("No session key", func(t *testing.T) {
+		t.Parallel()
+		_, err := issueSession(context.Background(), new(mockAuthRepository), &config.Config{}, "UserID1", 1234, client)
+		require.ErrorIs(t, err, auth.ErrInvalidSessionKey)
+	})
+
+	t.Run("StoreSession fails", func(t *testing.T) {
+		t.Parallel()
+		errStore := errors.New("store session error")
+		ar := new(mockAuthRepository)
+		ar.On("StoreSession", context.Background(), mock.Anything).Return(errStore)
+		_, err := issueSession(context.Background(), ar, cfg, "UserID1", 1234, client)
+		require.ErrorIs(t, err, errStore)
+	})
+}
+
+func Test_hashSessionID(t *testing.T) {
+	t.Parallel()
+	token, hash := newTestSession(t)
+
+	got, err := hashSessionID(&config.Config{SessionKey: testSessionKey}, token)
+	require.NoError(t, err)
+	require.Equal(t, hash, got)
+
+	got, err = hashSessionID(&config.Config{SessionKey: bytes.Repeat([]byte{1}, 32)}, token)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, got, "another key should give another hash")
+
+	_, err = hashSessionID(&config.Config{SessionKey: testSessionKey}, hash.String())
+	require.ErrorIs(t, err, ErrInvalidSessionID, "the stored hash should not be accepted as a token")
+
+	_, err = hashSessionID(&config.Config{}, token)
+	require.ErrorIs(t, err, auth.ErrInvalidSessionKey)
+}
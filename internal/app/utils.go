@@ -3,8 +3,8 @@ package app
 import (
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"log/slog"
-	"math"
 	"math/big"
 	mrand "math/rand"
 	"sync"
@@ -12,15 +12,66 @@ import (
 
 	"practical-case-test/config"
 	"practical-case-test/internal/domain/auth"
+	"practical-case-test/internal/group"
 )
 
 // ErrZeroQ is an error indicating that the value of q should not be 0.
+// ErrZeroP is an error indicating that the value of p should not be 0.
+// ErrNilGenerator is an error indicating that the generators g and h should be set.
 // ErrNilConfig is an error indicating that the config should not be nil.
 var (
-	ErrZeroQ     = errors.New("q cannot be 0")
-	ErrNilConfig = errors.New("config cannot be nil")
+	ErrZeroQ        = errors.New("q cannot be 0")
+	ErrZeroP        = errors.New("p cannot be 0")
+	ErrNilGenerator = errors.New("g and h cannot be nil")
+	ErrNilConfig    = errors.New("config cannot be nil")
 )
 
+// ErrIdentityElement is an error indicating that a value is the identity element of the group, which would
+// make the proof independent of the secret.
+var ErrIdentityElement = errors.New("element is the identity")
+
+// InvalidElementError is returned when a value received from a prover, such as y1 or r1, is not a usable
+// element of the prime-order group. Name identifies the offending value and Err is either
+// group.ErrInvalidElement, for values outside the group such as 0, values not below p or values outside
+// the order-q subgroup, or ErrIdentityElement.
+type InvalidElementError struct {
+	Name string
+	Err  error
+}
+
+func (e *InvalidElementError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Name, e.Err)
+}
+
+func (e *InvalidElementError) Unwrap() error {
+	return e.Err
+}
+
+// namedValue is an integer-encoded group element together with the name it is reported under.
+type namedValue struct {
+	name  string
+	value *big.Int
+}
+
+// validateElements checks that every value decodes to a non-identity element of the group described by cfg.
+// It returns the errors of newGroup, or an *InvalidElementError for the first value that fails.
+func validateElements(cfg *config.Config, values ...namedValue) error {
+	grp, _, _, err := newGroup(cfg)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		elems, err := intsToElements(grp, v.value)
+		if err != nil {
+			return &InvalidElementError{Name: v.name, Err: err}
+		}
+		if elems[0].Equal(grp.Identity()) {
+			return &InvalidElementError{Name: v.name, Err: ErrIdentityElement}
+		}
+	}
+	return nil
+}
+
 // charset is a constant string that contains all the lowercase and uppercase alphabets.
 const charset = "abcdefghijklmnopqrstuvwxyz" +
 	"ABCDEFGHIJKLMNOPQRSTUVWXYZ"
@@ -35,64 +86,75 @@ func RandString(length int) string {
 	return string(b)
 }
 
-// RandomPassword generates a random password as a big.Int using the math.MaxInt16 as the upper bound.
-func RandomPassword() (*big.Int, error) {
-	return rand.Int(rand.Reader, big.NewInt(math.MaxInt16))
+// randomPasswordLength is the length of the passwords generated by RandomPassword.
+const randomPasswordLength = 20
+
+// RandomPassword generates a random password of letters from 'charset' using a cryptographically secure source.
+func RandomPassword() (string, error) {
+	b := make([]byte, randomPasswordLength)
+	limit := big.NewInt(int64(len(charset)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", err
+		}
+		b[i] = charset[n.Int64()]
+	}
+	return string(b), nil
 }
 
 // calculateYs calculates y1 and y2 values based on the provided config and user password.
 // It returns the calculated y1 and y2 values along with any error that occurred.
 // If the given config is nil, it returns nil for both y1 and y2 and an error with the message "config cannot be nil".
-// If the length of config's Q value is 0, it returns nil for both y1 and y2 and an error with the message "q cannot be 0".
-// Otherwise, it calculates y1 as cfg.G^userPassword mod cfg.Q and y2 as cfg.H^userPassword mod cfg.Q.
+// If the group described by the config is incomplete, it returns nil for both y1 and y2 and the error from newGroup.
+// Otherwise, it reduces the password modulo the group order and calculates y1 as g^userPassword and
+// y2 as h^userPassword in the configured group, using the fixed-base tables of cfg when they were precomputed.
 // The function does not perform any logging or additional operations beyond the calculations.
 // It is important to note that the function assumes the correctness of the provided input parameters.
 func calculateYs(cfg *config.Config, userPassword *big.Int) (y1, y2 *big.Int, err error) {
-	if cfg == nil {
-		return nil, nil, ErrNilConfig
-	}
-	if len(cfg.Q.Bits()) == 0 {
-		return nil, nil, ErrZeroQ
+	grp, g, h, err := newGroup(cfg)
+	if err != nil {
+		return nil, nil, err
 	}
-	y1Tmp := new(big.Int).Exp(cfg.G, userPassword, nil)
-	y1 = new(big.Int).Mod(y1Tmp, cfg.Q)
+	x := new(big.Int).Mod(userPassword, grp.Order())
+	fg, fh := cfg.FixedBases()
 
-	y2Tmp := new(big.Int).Exp(cfg.H, userPassword, nil)
-	y2 = new(big.Int).Mod(y2Tmp, cfg.Q)
+	y1 = elementToInt(grp, fixedExp(grp, fg, g, x))
+	y2 = elementToInt(grp, fixedExp(grp, fh, h, x))
 
 	return
 }
 
 // calculateCommitment calculates the commitment values (r1, r2, and k) based on the provided config.
-// It generates a random number k using math.MaxInt16 as the maximum value.
-// It then adds 1 to k and logs the generated value.
-// It calculates r1 and r2 by exponentiating the values cfg.G and cfg.H to the power of k, respectively.
-// The results r1 and r2 are then computed modulo cfg.Q.
+// It generates a random scalar k uniformly in [1, q), which it keeps out of the logs since k and the response
+// s to any challenge c reveal the long-lived secret x = (k - s) * c^-1 mod q.
+// It calculates r1 and r2 by exponentiating the generators g and h to the power of k in the configured group,
+// using the fixed-base tables of cfg when they were precomputed.
 // The function runs the calculations concurrently using goroutines and waits for them to finish using a WaitGroup.
 // If an error occurs during the generation of k, it returns nil for all values and the error.
 // Otherwise, it returns the calculated values r1, r2, and k, along with nil error.
 func calculateCommitment(cfg *config.Config) (r1, r2, k *big.Int, err error) {
-	k, err = rand.Int(rand.Reader, big.NewInt(math.MaxInt16))
+	grp, g, h, err := newGroup(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	k, err = grp.RandomScalar(rand.Reader)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	k.Add(k, big.NewInt(1))
-
-	slog.Info("generating commitment", "k", k)
 
+	fg, fh := cfg.FixedBases()
 	var wg sync.WaitGroup
 	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
-		r1Tmp := new(big.Int).Exp(cfg.G, k, nil)
-		r1 = new(big.Int).Mod(r1Tmp, cfg.Q)
+		r1 = elementToInt(grp, fixedExp(grp, fg, g, k))
 	}()
 
 	go func() {
 		defer wg.Done()
-		r2Tmp := new(big.Int).Exp(cfg.H, k, nil)
-		r2 = new(big.Int).Mod(r2Tmp, cfg.Q)
+		r2 = elementToInt(grp, fixedExp(grp, fh, h, k))
 	}()
 
 	wg.Wait()
@@ -100,52 +162,128 @@ func calculateCommitment(cfg *config.Config) (r1, r2, k *big.Int, err error) {
 	return
 }
 
+// randomChallenge draws the challenge c of an interactive login. With cfg.ChallengeBits set below the bit
+// length of the group order it is uniform in [1, 2^ChallengeBits); otherwise it is uniform in [1, q), the full
+// range, so the soundness error of a single run is 1/(q-1).
+// It returns the errors of newGroup or of the random source.
+func randomChallenge(cfg *config.Config) (*big.Int, error) {
+	grp, _, _, err := newGroup(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ChallengeBits == 0 || int(cfg.ChallengeBits) >= grp.Order().BitLen() {
+		return grp.RandomScalar(rand.Reader)
+	}
+	upper := new(big.Int).Lsh(big.NewInt(1), cfg.ChallengeBits)
+	c, err := rand.Int(rand.Reader, upper.Sub(upper, big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+	return c.Add(c, big.NewInt(1)), nil
+}
+
 // calculateS calculates the value of s based on the given configuration, c, x, and k.
-// It calculates s as (k - (c * x)) % q, where q is obtained from cfg.Q.
+// It calculates s as (k - (c * x)) % q, where q is the order of the configured group.
 // The function returns the calculated value of s and an error, if any.
 // If the configuration is nil, it returns nil and an error indicating that the config cannot be nil.
 // If the value of q in the configuration is zero, it returns nil and an error indicating that q cannot be zero.
 func calculateS(cfg *config.Config, c, x, k *big.Int) (*big.Int, error) {
-	if cfg == nil {
-		return nil, ErrNilConfig
-	}
-	if len(cfg.Q.Bits()) == 0 {
-		return nil, ErrZeroQ
+	grp, _, _, err := newGroup(cfg)
+	if err != nil {
+		return nil, err
 	}
 	cx := new(big.Int).Mul(c, x)
 	kSubCx := new(big.Int).Sub(k, cx)
-	s := new(big.Int).Mod(kSubCx, cfg.Q)
+	s := new(big.Int).Mod(kSubCx, grp.Order())
 
 	return s, nil
 }
 
 // verifyS verifies the authenticity of the user's response to the authentication challenge
-// by performing calculations and comparing the results with the challenge's expected values.
-func verifyS(cfg *config.Config, challenge *auth.Challenge, user *auth.User, s int64) bool {
-	cBigInt := challenge.C()
-	y1BigInt := big.NewInt(user.Y1())
-	y2BigInt := big.NewInt(user.Y2())
-	sBigInt := big.NewInt(s)
-
-	gBigInt := cfg.G
-	hBigInt := cfg.H
-
-	leftR1 := new(big.Int).Exp(gBigInt, sBigInt, nil)
-	rightR1 := new(big.Int).Exp(y1BigInt, cBigInt, nil)
-	r1Tmp := new(big.Int).Mul(leftR1, rightR1)
-	r1 := new(big.Int).Mod(r1Tmp, cfg.Q)
-
-	leftR2 := new(big.Int).Exp(hBigInt, sBigInt, nil)
-	rightR2 := new(big.Int).Exp(y2BigInt, cBigInt, nil)
-	r2Tmp := new(big.Int).Mul(leftR2, rightR2)
-	r2 := new(big.Int).Mod(r2Tmp, cfg.Q)
-
-	slog.Info("r1", "r1 calculated locally", r1, "r1 received", challenge.R1())
-	slog.Info("r2", "r2 calculated locally", r2, "r2 received", challenge.R2())
-
-	if r1.Cmp(big.NewInt(challenge.R1())) != 0 || r2.Cmp(big.NewInt(challenge.R2())) != 0 {
+// by recomputing r1 = g^s * y1^c and r2 = h^s * y2^c in the configured group and comparing
+// them with the commitments stored in the challenge. Each product is computed with verifyProduct.
+func verifyS(cfg *config.Config, challenge *auth.Challenge, user *auth.User, s *big.Int) bool {
+	grp, g, h, err := newGroup(cfg)
+	if err != nil {
 		return false
 	}
+	elems, err := intsToElements(grp, user.Y1(), user.Y2(), challenge.R1(), challenge.R2())
+	if err != nil {
+		return false
+	}
+	y1, y2, wantR1, wantR2 := elems[0], elems[1], elems[2], elems[3]
+
+	fg, fh := cfg.FixedBases()
+	r1 := verifyProduct(grp, fg, g, y1, s, challenge.C())
+	r2 := verifyProduct(grp, fh, h, y2, s, challenge.C())
+
+	slog.Info("r1", "r1 calculated locally", elementToInt(grp, r1), "r1 received", challenge.R1())
+	slog.Info("r2", "r2 calculated locally", elementToInt(grp, r2), "r2 received", challenge.R2())
+
+	return r1.Equal(wantR1) && r2.Equal(wantR2)
+}
+
+// fixedExp returns base^k, looking it up in table when the generator was precomputed and falling back to
+// grp.Exp otherwise.
+func fixedExp(grp group.Group, table *group.FixedBase, base group.Element, k *big.Int) group.Element {
+	if table != nil {
+		return table.Exp(k)
+	}
+	return grp.Exp(base, k)
+}
+
+// verifyProduct returns base^s * y^c. Without a table for base it uses a single multi-exponentiation,
+// since s and c are public. With one, base^s costs only a fraction of an exponentiation, so it computes
+// y^c on its own instead, which for short challenges is far cheaper than squaring through all of s.
+func verifyProduct(grp group.Group, table *group.FixedBase, base, y group.Element, s, c *big.Int) group.Element {
+	if table == nil {
+		return grp.MultiExp([]group.Element{base, y}, []*big.Int{s, c})
+	}
+	return grp.Mul(table.Exp(s), grp.Exp(y, c))
+}
 
-	return true
+// newGroup returns the group backend described by cfg together with its generators g and h.
+// It returns ErrNilConfig, ErrZeroQ, ErrZeroP or ErrNilGenerator if cfg does not describe a
+// complete group, or the decoding error of g or h.
+func newGroup(cfg *config.Config) (grp group.Group, g, h group.Element, err error) {
+	if cfg == nil {
+		return nil, nil, nil, ErrNilConfig
+	}
+	if cfg.Q == nil || len(cfg.Q.Bits()) == 0 {
+		return nil, nil, nil, ErrZeroQ
+	}
+	if cfg.Group != config.GroupRistretto255 && (cfg.P == nil || len(cfg.P.Bits()) == 0) {
+		return nil, nil, nil, ErrZeroP
+	}
+	if cfg.G == nil || cfg.H == nil {
+		return nil, nil, nil, ErrNilGenerator
+	}
+	grp = cfg.NewGroup()
+	elems, err := intsToElements(grp, cfg.G, cfg.H)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return grp, elems[0], elems[1], nil
+}
+
+// elementToInt returns the encoding of e in grp as a big-endian integer, the form group
+// elements take in the domain and on the wire.
+func elementToInt(grp group.Group, e group.Element) *big.Int {
+	return new(big.Int).SetBytes(grp.Encode(e))
+}
+
+// intsToElements decodes integer-encoded group elements, failing on the first invalid one.
+func intsToElements(grp group.Group, values ...*big.Int) ([]group.Element, error) {
+	elems := make([]group.Element, len(values))
+	for i, v := range values {
+		if v == nil {
+			return nil, group.ErrInvalidElement
+		}
+		e, err := grp.Decode(v.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = e
+	}
+	return elems, nil
 }
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+)
+
+// ErrInvalidSessionID is an error indicating that a session ID is not a well-formed session token.
+// ErrUnauthenticated is an error indicating that the session a request is authenticated with is unknown or expired.
+// ErrPermissionDenied is an error indicating that a user who is not an admin acts on the sessions of another user.
+// ErrAdminCertificateRequired is an error indicating that a user listed as admin registers, or acts on the
+// sessions of another user, without a verified client certificate issued to their name.
+var (
+	ErrInvalidSessionID         = errors.New("session ID is not a valid session token")
+	ErrUnauthenticated          = errors.New("caller session is not valid")
+	ErrPermissionDenied         = errors.New("only admins may manage the sessions of another user")
+	ErrAdminCertificateRequired = errors.New("admins must present a client certificate issued to their user name")
+)
+
+// SessionStatus is the outcome of validating a session. Session is nil if the user has no session with
+// the requested ID; otherwise it is set together with ExpiresAt, the Unix time at which the session
+// expires, even if Valid is false because that time has passed. In a listing of sessions, Current marks
+// the session the request was made with.
+type SessionStatus struct {
+	Valid     bool
+	Session   *auth.Session
+	ExpiresAt int64
+	Current   bool
+}
+
+// ValidateSessionExecuter is an interface that defines the method for checking whether a session is live.
+type ValidateSessionExecuter interface {
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.ValidateSessionRequest) (*SessionStatus, error)
+}
+
+// ValidateSession is a type that is responsible for telling other services whether a session handed to
+// them by a user was opened by a successful login and has not expired.
+type ValidateSession struct {
+	ar repository.AuthRepository
+}
+
+// NewValidateSession creates a new instance of ValidateSessionExecuter with the provided AuthRepository.
+func NewValidateSession(ar repository.AuthRepository) ValidateSessionExecuter {
+	return &ValidateSession{ar: ar}
+}
+
+// Exec looks up the session of the user with the requested ID. A session that does not exist or that has
+// expired under cfg.SessionTTL and cfg.SessionIdleTTL, see auth.Session.ExpiresAt, is reported as not valid
+// rather than as an error. Validating a session does not refresh it.
+// It returns ErrNilConfig, ErrInvalidSessionID if the session ID is not a session token, or any other error
+// of hashSessionID or the repository.
+func (vs ValidateSession) Exec(ctx context.Context, cfg *config.Config,
+	req *interactor.ValidateSessionRequest) (*SessionStatus, error) {
+	userID := req.GetUser()
+
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	sessionID, err := hashSessionID(cfg, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := vs.ar.GetSession(ctx, userID, sessionID)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		slog.Info("session not found", "user", userID, "session", sessionID)
+		return &SessionStatus{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := session.ExpiresAt(cfg.SessionTTL, cfg.SessionIdleTTL)
+	status := &SessionStatus{
+		Valid:     time.Now().Before(expiresAt),
+		Session:   session,
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	slog.Info("session validated", "user", userID, "session", sessionID, "valid", status.Valid)
+
+	return status, nil
+}
+
+// authorizeSessionAccess checks that rawSessionID is a live session of caller and that caller may act on the
+// sessions of target, which is the case for caller itself and, for admins, for any user. An empty target
+// stands for caller. Admin rights need both the name of caller in cfg.AdminUsers and a request made by client,
+// the client of the current call, over a connection with a verified client certificate issued to caller: anyone
+// can register a name, and a session token alone can be stolen and replayed from anywhere.
+// It returns the resolved target and the ID of the caller's session, the hash of its token.
+// It returns ErrNilConfig, ErrInvalidSessionID, ErrUnauthenticated for an unknown or expired session,
+// ErrPermissionDenied, ErrAdminCertificateRequired, or any other error of the repository.
+func authorizeSessionAccess(ctx context.Context, ar repository.AuthRepository, cfg *config.Config,
+	caller, rawSessionID, target string, client auth.ClientInfo) (string, auth.SessionHash, error) {
+	if cfg == nil {
+		return "", auth.SessionHash{}, ErrNilConfig
+	}
+	sessionID, err := hashSessionID(cfg, rawSessionID)
+	if err != nil {
+		return "", auth.SessionHash{}, err
+	}
+
+	session, err := ar.GetSession(ctx, caller, sessionID)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return "", auth.SessionHash{}, ErrUnauthenticated
+	}
+	if err != nil {
+		return "", auth.SessionHash{}, err
+	}
+	if session.IsExpired(time.Now(), cfg.SessionTTL, cfg.SessionIdleTTL) {
+		return "", auth.SessionHash{}, ErrUnauthenticated
+	}
+
+	if target == "" {
+		target = caller
+	}
+	if target != caller {
+		if !cfg.IsAdmin(caller) {
+			return "", auth.SessionHash{}, ErrPermissionDenied
+		}
+		if client.CertificateName() != caller {
+			return "", auth.SessionHash{}, ErrAdminCertificateRequired
+		}
+	}
+	return target, sessionID, nil
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/domain/auth"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSession_Exec(t *testing.T) {
+	cfg := &config.Config{SessionKey: testSessionKey, SessionTTL: time.Hour, SessionIdleTTL: 30 * time.Minute}
+
+	uID := "UserID1"
+	token, sessionID := newTestSession(t)
+	now := time.Now()
+	live, err := auth.NewSession(sessionID, uID, now.Unix())
+	require.NoError(t, err)
+	opened, err := auth.NewSession(sessionID, uID, now.Add(-2*time.Hour).Unix())
+	require.NoError(t, err)
+	// Refreshed recently, but past its absolute lifetime.
+	expired := opened.Refreshed(now.Add(-5 * time.Minute).Unix())
+	idle, err := auth.NewSession(sessionID, uID, now.Add(-40*time.Minute).Unix())
+	require.NoError(t, err)
+
+	req := &interactor.ValidateSessionRequest{User: uID, SessionId: token}
+
+	testCases := []struct {
+		name    string
+		cfg     *config.Config
+		request *interactor.ValidateSessionRequest
+		setup   func(ar *mockAuthRepository)
+		want    *SessionStatus
+		wantErr error
+	}{
+		{
+			name:    "Live session",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(live, nil)
+			},
+			want: &SessionStatus{Valid: true, Session: live, ExpiresAt: now.Add(30 * time.Minute).Unix()},
+		},
+		{
+			name:    "Expired session",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(&expired, nil)
+			},
+			want: &SessionStatus{Valid: false, Session: &expired, ExpiresAt: now.Add(-time.Hour).Unix()},
+		},
+		{
+			name:    "Idle session",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(idle, nil)
+			},
+			want: &SessionStatus{Valid: false, Session: idle, ExpiresAt: now.Add(-10 * time.Minute).Unix()},
+		},
+		{
+			name:    "Unknown session",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(nil, repository.ErrSessionNotFound)
+			},
+			want: &SessionStatus{},
+		},
+		{
+			name:    "GetSession fails",
+			cfg:     cfg,
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("GetSession", context.Background(), uID, sessionID).Return(nil, context.Canceled)
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name:    "Malformed session ID",
+			cfg:     cfg,
+			request: &interactor.ValidateSessionRequest{User: uID, SessionId: "not-a-token"},
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrInvalidSessionID,
+		},
+		{
+			name:    "Nil config",
+			request: req,
+			setup:   func(*mockAuthRepository) {},
+			wantErr: ErrNilConfig,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ar := new(mockAuthRepository)
+			tt.setup(ar)
+			got, err := NewValidateSession(ar).Exec(context.Background(), tt.cfg, tt.request)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+			ar.AssertExpectations(t)
+		})
+	}
+}
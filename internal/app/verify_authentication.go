@@ -4,20 +4,28 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"math/big"
 	"time"
 
 	"practical-case-test/config"
 	"practical-case-test/internal/domain/auth"
 	interactor "practical-case-test/internal/interactor/proto"
 	"practical-case-test/internal/repository"
+)
 
-	"github.com/google/uuid"
+// ErrChallengeExpired is an error indicating that a challenge is answered more than the configured
+// ChallengeTTL after it was issued.
+// ErrInvalidResponse is an error indicating that the response s to a challenge does not prove knowledge
+// of the secret of the user.
+var (
+	ErrChallengeExpired = errors.New("challenge expired")
+	ErrInvalidResponse  = errors.New("verification failed, Invalid s")
 )
 
 // VerifyAuthenticationExecuter is an interface that defines the contract for executing
 // the verification of authentication information.
 type VerifyAuthenticationExecuter interface {
-	Exec(ctx context.Context, cfg *config.Config, req *interactor.AuthenticationAnswerRequest) (*auth.Session, error)
+	Exec(ctx context.Context, cfg *config.Config, req *interactor.AuthenticationAnswerRequest) (*IssuedSession, error)
 }
 
 // VerifyAuthentication is a type that is responsible for verifying the authentication
@@ -32,41 +40,58 @@ func NewVerifyAuthentication(ar repository.AuthRepository) VerifyAuthenticationE
 	return &VerifyAuthentication{ar: ar}
 }
 
-// Exec retrieves the authentication challenge for the given authID,
-// verifies the user's response, and creates a new session for the user.
-// It returns the newly created session, or an error if any operation fails.
+// Exec consumes the authentication challenge for the given authID, so that it cannot be answered
+// again whatever the outcome, rejects it with ErrChallengeExpired if it was issued more than
+// cfg.ChallengeTTL ago, verifies the user's response to the challenge bound with bindChallenge to the
+// TLS connection the challenge was requested on, and issues a new session for the user with
+// issueSession, attributed to the client the challenge was requested from.
+// It returns the newly issued session with its token, ErrInvalidResponse if s does not verify, or an error
+// if any other operation fails.
 func (va VerifyAuthentication) Exec(ctx context.Context, cfg *config.Config,
-	req *interactor.AuthenticationAnswerRequest) (*auth.Session, error) {
+	req *interactor.AuthenticationAnswerRequest) (*IssuedSession, error) {
 	authID := req.GetAuthId()
-	s := req.GetS()
+	s := new(big.Int).SetBytes(req.GetSBytes())
+
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
 
-	challenge, err := va.ar.GetAuthenticationChallenge(ctx, authID)
+	challenge, err := va.ar.ConsumeAuthenticationChallenge(ctx, authID)
 	if err != nil {
 		return nil, err
 	}
 
-	slog.Info("challenge loaded", "challenge", challenge)
+	slog.Info("challenge consumed", "challenge", challenge)
+
+	if time.Since(time.Unix(challenge.Timestamp(), 0)) > cfg.ChallengeTTL {
+		return nil, ErrChallengeExpired
+	}
 
 	user, err := va.ar.GetUserRegistration(ctx, challenge.UserID())
 	if err != nil {
 		return nil, err
 	}
 
-	if ok := verifyS(cfg, challenge, user, s); !ok {
-		return nil, errors.New("verification failed, Invalid s")
+	c, err := bindChallenge(cfg, challenge.C(), challenge.Client().ChannelBinding())
+	if err != nil {
+		return nil, err
 	}
-
-	session, err := auth.NewSession(uuid.New(), user.UserID(), time.Now().Unix())
+	bound, err := auth.NewChallenge(c, challenge.UserID(), challenge.R1(), challenge.R2(), challenge.Timestamp())
 	if err != nil {
 		return nil, err
 	}
 
-	err = va.ar.StoreSession(ctx, *session)
+	if ok := verifyS(cfg, bound, user, s); !ok {
+		return nil, ErrInvalidResponse
+	}
+
+	issued, err := issueSession(ctx, va.ar, cfg, user.UserID(), time.Now().Unix(), challenge.Client())
 	if err != nil {
 		return nil, err
 	}
 
-	slog.Info("session initiated", "user", user.UserID(), "session", session.ID())
+	slog.Info("session initiated", "user", user.UserID(), "session", issued.Session.ID(),
+		"peer", challenge.Client().PeerAddress(), "device", challenge.Client().DeviceLabel())
 
-	return session, nil
+	return issued, nil
 }
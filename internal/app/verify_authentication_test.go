@@ -3,8 +3,12 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -16,76 +20,152 @@ import (
 
 func TestVerifyAuthentication_Exec(t *testing.T) {
 	cfg := &config.Config{
-		G: big.NewInt(3),
-		H: big.NewInt(5),
-		Q: big.NewInt(13),
+		G: big.NewInt(4),
+		H: big.NewInt(9),
+		P: big.NewInt(23),
+		Q: big.NewInt(11),
+
+		ChallengeTTL: time.Minute,
+		SessionKey:   testSessionKey,
 	}
 
 	uID := "UserID1"
 	authID := "AuthId1"
 
-	var y1, y2 int64 = 6, 8
-	var r1, r2 int64 = 7, 5
-	var c = big.NewInt(11)
-	var s int64 = 4
+	// x = 3, k = 5: y1 = 4^3, y2 = 9^3, r1 = 4^5, r2 = 9^5 mod 23 and s = (k - c*x) mod 11.
+	var y1, y2 = big.NewInt(18), big.NewInt(16)
+	var r1, r2 = big.NewInt(12), big.NewInt(8)
+	var c = big.NewInt(7)
+	var s = big.NewInt(6)
+
+	user, _ := auth.NewUser(uID, y1, y2, testSalt, testKDF)
+	client := auth.NewClientInfo("192.0.2.1:54321", "zkp-prover", "phone")
+	fresh, _ := auth.NewChallenge(c, uID, r1, r2, time.Now().Unix())
+	requested := fresh.WithClient(client)
+	challenge := &requested
+	expired, _ := auth.NewChallenge(c, uID, r1, r2, time.Now().Add(-2*time.Minute).Unix())
 
-	user, _ := auth.NewUser(uID, y1, y2)
-	challenge, _ := auth.NewChallenge(c, uID, r1, r2, 123456789)
+	// A challenge requested over TLS is answered bound to the exporter value of its connection.
+	boundRequested := fresh.WithClient(client.WithChannelBinding([]byte("channel A")))
+	boundChallenge := &boundRequested
+	res := &interactor.AuthenticationChallengeResponse{CBytes: c.Bytes()}
+	boundS, err := NewComputeS().Exec(cfg, big.NewInt(3), big.NewInt(5), res, []byte("channel A"))
+	require.NoError(t, err)
+	relayedS, err := NewComputeS().Exec(cfg, big.NewInt(3), big.NewInt(5), res, []byte("channel B"))
+	require.NoError(t, err)
 
 	req := &interactor.AuthenticationAnswerRequest{
 		AuthId: authID,
-		S:      s,
+		SBytes: s.Bytes(),
 	}
 
 	testCases := []struct {
 		name    string
 		request *interactor.AuthenticationAnswerRequest
 		setup   func(ar *mockAuthRepository)
-		check   func(*auth.Session, error)
+		check   func(*IssuedSession, error)
 	}{
 		{
 			name:    "Successful Path",
 			request: req,
 			setup: func(ar *mockAuthRepository) {
 				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
-				ar.On("GetAuthenticationChallenge", context.Background(), authID).Return(challenge, nil)
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(challenge, nil)
 				ar.On("StoreSession", context.Background(), mock.Anything).Return(nil)
 			},
-			check: func(s *auth.Session, err error) {
+			check: func(issued *IssuedSession, err error) {
 				require.NoError(t, err)
-				require.NotNil(t, s)
+				require.NotNil(t, issued)
+				require.NotEmpty(t, issued.Token)
+				require.Equal(t, client, issued.Session.Client(), "the session should be attributed to the client of the challenge")
 			},
 		},
 		{
-			name:    "GetAuthenticationChallenge fails",
+			name:    "ConsumeAuthenticationChallenge fails",
 			request: req,
 			setup: func(ar *mockAuthRepository) {
-				ar.On("GetAuthenticationChallenge", context.Background(), authID).Return(nil, errors.New("GetAuthenticationChallenge error"))
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(nil, errors.New("ConsumeAuthenticationChallenge error"))
 			},
-			check: func(_ *auth.Session, err error) {
+			check: func(_ *IssuedSession, err error) {
 				require.Error(t, err)
 			},
 		},
+		{
+			name:    "Expired challenge",
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(expired, nil)
+			},
+			check: func(_ *IssuedSession, err error) {
+				require.ErrorIs(t, err, ErrChallengeExpired)
+			},
+		},
 		{
 			name:    "GetUserRegistration fails",
 			request: req,
 			setup: func(ar *mockAuthRepository) {
-				ar.On("GetAuthenticationChallenge", context.Background(), authID).Return(challenge, nil)
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(challenge, nil)
 				ar.On("GetUserRegistration", context.Background(), challenge.UserID()).Return(nil, errors.New("GetUserRegistration error"))
 			},
-			check: func(_ *auth.Session, err error) {
+			check: func(_ *IssuedSession, err error) {
 				require.Error(t, err)
 			},
 		},
+		{
+			name:    "Invalid s",
+			request: &interactor.AuthenticationAnswerRequest{AuthId: authID, SBytes: big.NewInt(5).Bytes()},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(challenge, nil)
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+			},
+			check: func(_ *IssuedSession, err error) {
+				require.ErrorIs(t, err, ErrInvalidResponse)
+			},
+		},
+		{
+			name:    "Response bound to the channel of the challenge",
+			request: &interactor.AuthenticationAnswerRequest{AuthId: authID, SBytes: boundS.Bytes()},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(boundChallenge, nil)
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+				ar.On("StoreSession", context.Background(), mock.Anything).Return(nil)
+			},
+			check: func(issued *IssuedSession, err error) {
+				require.NoError(t, err)
+				require.Nil(t, issued.Session.Client().ChannelBinding(), "the session should not keep the channel binding")
+			},
+		},
+		{
+			name:    "Unbound response to a challenge requested over TLS",
+			request: req,
+			setup: func(ar *mockAuthRepository) {
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(boundChallenge, nil)
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+			},
+			check: func(_ *IssuedSession, err error) {
+				require.ErrorIs(t, err, ErrInvalidResponse)
+			},
+		},
+		{
+			name:    "Response relayed from another channel",
+			request: &interactor.AuthenticationAnswerRequest{AuthId: authID, SBytes: relayedS.Bytes()},
+			setup: func(ar *mockAuthRepository) {
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(boundChallenge, nil)
+				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
+			},
+			check: func(_ *IssuedSession, err error) {
+				require.ErrorIs(t, err, ErrInvalidResponse)
+			},
+		},
 		{
 			name:    "StoreSession fails",
 			request: req,
 			setup: func(ar *mockAuthRepository) {
 				ar.On("GetUserRegistration", context.Background(), uID).Return(user, nil)
-				ar.On("GetAuthenticationChallenge", context.Background(), authID).Return(challenge, nil)
+				ar.On("ConsumeAuthenticationChallenge", context.Background(), authID).Return(challenge, nil)
 				ar.On("StoreSession", context.Background(), mock.Anything).Return(errors.New("Store Session Error"))
 			},
-			check: func(_ *auth.Session, err error) {
+			check: func(_ *IssuedSession, err error) {
 				require.Error(t, err)
 			},
 		},
@@ -103,3 +183,136 @@ func TestVerifyAuthentication_Exec(t *testing.T) {
 		})
 	}
 }
+
+func Test_verifyS(t *testing.T) {
+	for _, name := range []string{config.GroupMODP2048, config.GroupRistretto255} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("ZKP_GROUP", name)
+			cfg, err := config.LoadConfig()
+			require.NoError(t, err)
+			precomputed := *cfg
+			require.NoError(t, precomputed.Precompute())
+			fg, fh := precomputed.FixedBases()
+			require.NotNil(t, fg)
+			require.NotNil(t, fh)
+
+			// The prover and the verifier may each run with or without tables, so every combination must agree.
+			for _, prover := range []*config.Config{cfg, &precomputed} {
+				x := big.NewInt(123456789)
+				y1, y2, err := calculateYs(prover, x)
+				require.NoError(t, err)
+				user, err := auth.NewUser("user", y1, y2, testSalt, testKDF)
+				require.NoError(t, err)
+
+				r1, r2, k, err := calculateCommitment(prover)
+				require.NoError(t, err)
+				challenge, err := auth.NewChallenge(big.NewInt(31337), "user", r1, r2, 123456789)
+				require.NoError(t, err)
+
+				s, err := calculateS(prover, challenge.C(), x, k)
+				require.NoError(t, err)
+				wrongS, err := calculateS(prover, challenge.C(), big.NewInt(3), k)
+				require.NoError(t, err)
+
+				for _, verifier := range []*config.Config{cfg, &precomputed} {
+					require.True(t, verifyS(verifier, challenge, user, s), "valid response should verify")
+					require.False(t, verifyS(verifier, challenge, user, wrongS), "response for another secret should not verify")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkVerifyS measures the verification of one response in every preset group.
+func BenchmarkVerifyS(b *testing.B) {
+	for _, name := range config.Groups() {
+		b.Run(name, func(b *testing.B) {
+			b.Setenv("ZKP_GROUP", name)
+			cfg, err := config.LoadConfig()
+			require.NoError(b, err)
+
+			x := big.NewInt(123456789)
+			y1, y2, err := calculateYs(cfg, x)
+			require.NoError(b, err)
+			user, err := auth.NewUser("user", y1, y2, testSalt, testKDF)
+			require.NoError(b, err)
+			r1, r2, k, err := calculateCommitment(cfg)
+			require.NoError(b, err)
+			c, err := randomChallenge(cfg)
+			require.NoError(b, err)
+			challenge, err := auth.NewChallenge(c, "user", r1, r2, 123456789)
+			require.NoError(b, err)
+			s, err := calculateS(cfg, c, x, k)
+			require.NoError(b, err)
+
+			b.ResetTimer()
+			for range b.N {
+				if !verifyS(cfg, challenge, user, s) {
+					b.Fatal("valid response should verify")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLogin measures the group arithmetic of one interactive login, the commitment and response of the
+// prover and the verification, in every preset group. It compares generic exponentiation against the
+// fixed-base tables of Precompute, with full-range challenges and with 128-bit ones.
+func BenchmarkLogin(b *testing.B) {
+	// Every login logs its commitment and results, which would drown the benchmark output.
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	b.Cleanup(func() { slog.SetDefault(prev) })
+
+	for _, name := range config.Groups() {
+		b.Run(name, func(b *testing.B) {
+			b.Setenv("ZKP_GROUP", name)
+			cfg, err := config.LoadConfig()
+			require.NoError(b, err)
+
+			x := big.NewInt(123456789)
+			y1, y2, err := calculateYs(cfg, x)
+			require.NoError(b, err)
+			user, err := auth.NewUser("user", y1, y2, testSalt, testKDF)
+			require.NoError(b, err)
+
+			for _, precompute := range []bool{false, true} {
+				for _, bits := range []uint{0, 128} {
+					c := *cfg
+					c.ChallengeBits = bits
+					if !precompute {
+						c.PrecomputeWindow = 0
+					}
+					require.NoError(b, c.Precompute())
+
+					mode := "generic"
+					if precompute {
+						mode = "precomputed"
+					}
+					b.Run(fmt.Sprintf("%s/challenge-bits=%d", mode, bits), func(b *testing.B) {
+						for range b.N {
+							benchmarkLogin(b, &c, user, x)
+						}
+					})
+				}
+			}
+		})
+	}
+}
+
+// benchmarkLogin runs the arithmetic of one interactive login for user, whose secret is x, and fails b if
+// the response does not verify.
+func benchmarkLogin(b *testing.B, cfg *config.Config, user *auth.User, x *big.Int) {
+	b.Helper()
+	r1, r2, k, err := calculateCommitment(cfg)
+	require.NoError(b, err)
+	c, err := randomChallenge(cfg)
+	require.NoError(b, err)
+	challenge, err := auth.NewChallenge(c, user.UserID(), r1, r2, 123456789)
+	require.NoError(b, err)
+	s, err := calculateS(cfg, c, x, k)
+	require.NoError(b, err)
+	if !verifyS(cfg, challenge, user, s) {
+		b.Fatal("valid response should verify")
+	}
+}
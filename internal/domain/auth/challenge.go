@@ -15,16 +15,17 @@ type Challenge struct {
 	userID    string
 	authID    uuid.UUID
 	c         *big.Int
-	r1        int64
-	r2        int64
+	r1        *big.Int
+	r2        *big.Int
 	timestamp int64
+	client    ClientInfo
 }
 
-func (c Challenge) R1() int64 {
+func (c Challenge) R1() *big.Int {
 	return c.r1
 }
 
-func (c Challenge) R2() int64 {
+func (c Challenge) R2() *big.Int {
 	return c.r2
 }
 
@@ -44,7 +45,18 @@ func (c Challenge) Timestamp() int64 {
 	return c.timestamp
 }
 
-func NewChallenge(c *big.Int, userID string, r1, r2, timestamp int64) (*Challenge, error) {
+// Client returns the client the challenge was requested from.
+func (c Challenge) Client() ClientInfo {
+	return c.client
+}
+
+// WithClient returns a copy of the challenge requested from client.
+func (c Challenge) WithClient(client ClientInfo) Challenge {
+	c.client = client
+	return c
+}
+
+func NewChallenge(c *big.Int, userID string, r1, r2 *big.Int, timestamp int64) (*Challenge, error) {
 	authID := uuid.New()
 	ch := &Challenge{
 		userID:    userID,
@@ -18,8 +18,8 @@ func TestChallenge_IsValid(t *testing.T) {
 		userID    string
 		authID    uuid.UUID
 		c         *big.Int
-		r1        int64
-		r2        int64
+		r1        *big.Int
+		r2        *big.Int
 		timestamp int64
 	}
 	tests := []struct {
@@ -119,7 +119,7 @@ func TestNewChallenge(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := NewChallenge(tt.args.c, tt.args.userID, 2, 4, tt.args.timestamp)
+			got, err := NewChallenge(tt.args.c, tt.args.userID, big.NewInt(2), big.NewInt(4), tt.args.timestamp)
 			if tt.wantErr {
 				require.Error(t, err, "NewChallenge() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,83 @@
+package auth
+
+import "unicode/utf8"
+
+// Longest peer address, user agent and device label, in characters, that a ClientInfo keeps. Longer values are
+// cut, so that a client cannot make the verifier store arbitrary amounts of data.
+const (
+	MaxPeerAddressLength = 128
+	MaxUserAgentLength   = 256
+	MaxDeviceLabelLength = 64
+)
+
+// ClientInfo describes the client a challenge was requested or a session was opened from: the network
+// address of the peer, the user agent it sent, the label it chose for its device, the channel binding of
+// its TLS connection and the name its verified client certificate was issued to. Each of them may be empty.
+type ClientInfo struct {
+	peerAddress     string
+	userAgent       string
+	deviceLabel     string
+	channelBinding  string
+	certificateName string
+}
+
+// NewClientInfo returns the ClientInfo for the given peer address, user agent and device label, cut to
+// MaxPeerAddressLength, MaxUserAgentLength and MaxDeviceLabelLength characters.
+func NewClientInfo(peerAddress, userAgent, deviceLabel string) ClientInfo {
+	return ClientInfo{
+		peerAddress: truncate(peerAddress, MaxPeerAddressLength),
+		userAgent:   truncate(userAgent, MaxUserAgentLength),
+		deviceLabel: truncate(deviceLabel, MaxDeviceLabelLength),
+	}
+}
+
+// PeerAddress returns the network address the client connected from.
+func (c ClientInfo) PeerAddress() string {
+	return c.peerAddress
+}
+
+// UserAgent returns the user agent the client sent.
+func (c ClientInfo) UserAgent() string {
+	return c.userAgent
+}
+
+// DeviceLabel returns the label the client gave its device, such as "work laptop".
+func (c ClientInfo) DeviceLabel() string {
+	return c.deviceLabel
+}
+
+// ChannelBinding returns the TLS exporter value of the connection of the client the response to a challenge
+// is bound to, or nil if it did not connect over TLS.
+func (c ClientInfo) ChannelBinding() []byte {
+	if c.channelBinding == "" {
+		return nil
+	}
+	return []byte(c.channelBinding)
+}
+
+// WithChannelBinding returns a copy of the ClientInfo whose connection has the given channel binding.
+func (c ClientInfo) WithChannelBinding(channelBinding []byte) ClientInfo {
+	c.channelBinding = string(channelBinding)
+	return c
+}
+
+// CertificateName returns the common name of the client certificate the verifier checked the client's TLS
+// connection with, or "" if the client presented none.
+func (c ClientInfo) CertificateName() string {
+	return c.certificateName
+}
+
+// WithCertificateName returns a copy of the ClientInfo whose connection presented a verified client
+// certificate issued to certificateName.
+func (c ClientInfo) WithCertificateName(certificateName string) ClientInfo {
+	c.certificateName = certificateName
+	return c
+}
+
+// truncate returns the first n characters of s.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
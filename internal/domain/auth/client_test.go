@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientInfo(t *testing.T) {
+	tests := []struct {
+		name                           string
+		peerAddress, userAgent, label  string
+		wantPeer, wantAgent, wantLabel string
+	}{
+		{
+			name:        "Short values are kept",
+			peerAddress: "192.0.2.1:54321",
+			userAgent:   "zkp-prover grpc-go/1.65.0",
+			label:       "work laptop",
+			wantPeer:    "192.0.2.1:54321",
+			wantAgent:   "zkp-prover grpc-go/1.65.0",
+			wantLabel:   "work laptop",
+		},
+		{
+			name: "Empty values",
+		},
+		{
+			name:        "Long values are cut",
+			peerAddress: strings.Repeat("a", MaxPeerAddressLength+1),
+			userAgent:   strings.Repeat("b", MaxUserAgentLength+10),
+			label:       strings.Repeat("é", MaxDeviceLabelLength+1),
+			wantPeer:    strings.Repeat("a", MaxPeerAddressLength),
+			wantAgent:   strings.Repeat("b", MaxUserAgentLength),
+			wantLabel:   strings.Repeat("é", MaxDeviceLabelLength),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NewClientInfo(tt.peerAddress, tt.userAgent, tt.label)
+			require.Equal(t, tt.wantPeer, got.PeerAddress())
+			require.Equal(t, tt.wantAgent, got.UserAgent())
+			require.Equal(t, tt.wantLabel, got.DeviceLabel())
+			require.Nil(t, got.ChannelBinding())
+		})
+	}
+}
+
+func TestWithChannelBinding(t *testing.T) {
+	t.Parallel()
+	client := NewClientInfo("192.0.2.1:54321", "zkp-prover", "phone")
+
+	bound := client.WithChannelBinding([]byte{1, 2, 3})
+	require.Equal(t, []byte{1, 2, 3}, bound.ChannelBinding())
+	require.Equal(t, client.PeerAddress(), bound.PeerAddress())
+	require.Nil(t, client.ChannelBinding(), "WithChannelBinding should not change the original client")
+	require.Nil(t, bound.WithChannelBinding(nil).ChannelBinding())
+	require.Equal(t, client, bound.WithChannelBinding(nil))
+}
+
+func TestWithCertificateName(t *testing.T) {
+	t.Parallel()
+	client := NewClientInfo("192.0.2.1:54321", "zkp-prover", "phone")
+
+	named := client.WithCertificateName("alice")
+	require.Equal(t, "alice", named.CertificateName())
+	require.Equal(t, client.DeviceLabel(), named.DeviceLabel())
+	require.Empty(t, client.CertificateName(), "WithCertificateName should not change the original client")
+}
+
+func TestWithClient(t *testing.T) {
+	t.Parallel()
+	client := NewClientInfo("192.0.2.1:54321", "zkp-prover", "phone")
+
+	challenge, err := NewChallenge(testBigInt, "test_user", big.NewInt(2), big.NewInt(4), 1598896296)
+	require.NoError(t, err)
+	withClient := challenge.WithClient(client)
+	require.Equal(t, client, withClient.Client())
+	require.Equal(t, challenge.AuthID(), withClient.AuthID())
+	require.Equal(t, ClientInfo{}, challenge.Client(), "WithClient should not change the original challenge")
+
+	session, err := NewSession(SessionHash{1}, "test_user", 1598896296)
+	require.NoError(t, err)
+	opened := session.WithClient(client)
+	require.Equal(t, client, opened.Client())
+	require.Equal(t, client, opened.Refreshed(1598896300).Client())
+	require.Equal(t, ClientInfo{}, session.Client(), "WithClient should not change the original session")
+}
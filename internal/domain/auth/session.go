@@ -2,8 +2,7 @@ package auth
 
 import (
 	"errors"
-
-	"github.com/google/uuid"
+	"time"
 )
 
 var (
@@ -11,12 +10,15 @@ var (
 )
 
 type Session struct {
-	id             uuid.UUID
-	userID         string
-	loginTimestamp int64
+	id                SessionHash
+	userID            string
+	loginTimestamp    int64
+	lastSeenTimestamp int64
+	client            ClientInfo
 }
 
-func (s Session) ID() uuid.UUID {
+// ID returns the hash of the token the session was issued with, see HashSessionToken.
+func (s Session) ID() SessionHash {
 	return s.id
 }
 
@@ -28,11 +30,50 @@ func (s Session) LoginTimestamp() int64 {
 	return s.loginTimestamp
 }
 
-func NewSession(id uuid.UUID, userID string, loginTimestamp int64) (*Session, error) {
+// LastSeenTimestamp returns the Unix time the session was opened or last refreshed at.
+func (s Session) LastSeenTimestamp() int64 {
+	return s.lastSeenTimestamp
+}
+
+// Client returns the client the session was opened from.
+func (s Session) Client() ClientInfo {
+	return s.client
+}
+
+// WithClient returns a copy of the session opened from client.
+func (s Session) WithClient(client ClientInfo) Session {
+	s.client = client
+	return s
+}
+
+// Refreshed returns a copy of the session last seen at the given Unix time.
+func (s Session) Refreshed(lastSeenTimestamp int64) Session {
+	s.lastSeenTimestamp = lastSeenTimestamp
+	return s
+}
+
+// ExpiresAt returns the time at which the session expires: absoluteTTL after the login or idleTTL after it was
+// last seen, whichever comes first.
+func (s Session) ExpiresAt(absoluteTTL, idleTTL time.Duration) time.Time {
+	absolute := time.Unix(s.loginTimestamp, 0).Add(absoluteTTL)
+	idle := time.Unix(s.lastSeenTimestamp, 0).Add(idleTTL)
+	if idle.Before(absolute) {
+		return idle
+	}
+	return absolute
+}
+
+// IsExpired reports whether the session has expired at now under the given TTLs, see ExpiresAt.
+func (s Session) IsExpired(now time.Time, absoluteTTL, idleTTL time.Duration) bool {
+	return !now.Before(s.ExpiresAt(absoluteTTL, idleTTL))
+}
+
+func NewSession(id SessionHash, userID string, loginTimestamp int64) (*Session, error) {
 	s := &Session{
-		id:             id,
-		userID:         userID,
-		loginTimestamp: loginTimestamp,
+		id:                id,
+		userID:            userID,
+		loginTimestamp:    loginTimestamp,
+		lastSeenTimestamp: loginTimestamp,
 	}
 	if !s.IsValid() {
 		return nil, ErrInvalidSession
@@ -41,5 +82,5 @@ func NewSession(id uuid.UUID, userID string, loginTimestamp int64) (*Session, er
 }
 
 func (s Session) IsValid() bool {
-	return s.id != uuid.Nil && s.userID != ""
+	return !s.id.IsZero() && s.userID != ""
 }
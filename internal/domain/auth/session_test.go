@@ -2,8 +2,8 @@ package auth
 
 import (
 	"testing"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,16 +16,16 @@ func TestNewSession(t *testing.T) {
 		{
 			name: "Valid: regular condition",
 			args: Session{
-				id:             uuid.New(),
+				id:             SessionHash{1},
 				userID:         "user_id",
 				loginTimestamp: 1598896296,
 			},
 			wantErr: false,
 		},
 		{
-			name: "Invalid: empty uuid",
+			name: "Invalid: zero hash",
 			args: Session{
-				id:             uuid.UUID{},
+				id:             SessionHash{},
 				userID:         "user_id",
 				loginTimestamp: 1598896296,
 			},
@@ -34,7 +34,7 @@ func TestNewSession(t *testing.T) {
 		{
 			name: "Invalid: empty userID",
 			args: Session{
-				id:             uuid.New(),
+				id:             SessionHash{1},
 				userID:         "",
 				loginTimestamp: 1598896296,
 			},
@@ -56,7 +56,7 @@ func TestNewSession(t *testing.T) {
 
 func TestSession_IsValid(t *testing.T) {
 	type fields struct {
-		id             uuid.UUID
+		id             SessionHash
 		userID         string
 		loginTimestamp int64
 	}
@@ -68,16 +68,16 @@ func TestSession_IsValid(t *testing.T) {
 		{
 			name: "Valid: regular condition",
 			fields: fields{
-				id:             uuid.New(),
+				id:             SessionHash{1},
 				userID:         "user_id",
 				loginTimestamp: 1598896296,
 			},
 			want: true,
 		},
 		{
-			name: "Invalid: empty uuid",
+			name: "Invalid: zero hash",
 			fields: fields{
-				id:             uuid.UUID{},
+				id:             SessionHash{},
 				userID:         "user_id",
 				loginTimestamp: 1598896296,
 			},
@@ -86,7 +86,7 @@ func TestSession_IsValid(t *testing.T) {
 		{
 			name: "Invalid: empty userID",
 			fields: fields{
-				id:             uuid.New(),
+				id:             SessionHash{1},
 				userID:         "",
 				loginTimestamp: 1598896296,
 			},
@@ -106,3 +106,34 @@ func TestSession_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_ExpiresAt(t *testing.T) {
+	login := time.Unix(1598896296, 0)
+	session, err := NewSession(SessionHash{1}, "user_id", login.Unix())
+	require.NoError(t, err)
+	require.Equal(t, login.Unix(), session.LastSeenTimestamp(), "a new session should be last seen at login")
+
+	tests := []struct {
+		name     string
+		lastSeen time.Time
+		want     time.Time
+	}{
+		{name: "idle timeout first", lastSeen: login, want: login.Add(30 * time.Minute)},
+		{name: "refreshed within the absolute lifetime", lastSeen: login.Add(time.Hour), want: login.Add(90 * time.Minute)},
+		{name: "absolute timeout first", lastSeen: login.Add(23*time.Hour + 45*time.Minute), want: login.Add(24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			refreshed := session.Refreshed(tt.lastSeen.Unix())
+			require.Equal(t, tt.lastSeen.Unix(), refreshed.LastSeenTimestamp())
+			require.Equal(t, login.Unix(), refreshed.LoginTimestamp(), "refreshing should keep the login time")
+
+			got := refreshed.ExpiresAt(24*time.Hour, 30*time.Minute)
+			require.Equal(t, tt.want, got)
+			require.False(t, refreshed.IsExpired(got.Add(-time.Second), 24*time.Hour, 30*time.Minute))
+			require.True(t, refreshed.IsExpired(got, 24*time.Hour, 30*time.Minute))
+		})
+	}
+	require.Equal(t, login.Unix(), session.LastSeenTimestamp(), "Refreshed should not modify the original session")
+}
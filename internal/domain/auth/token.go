@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SessionTokenBytes is the number of random bytes, 256 bits, in a session token.
+const SessionTokenBytes = 32
+
+var (
+	ErrInvalidSessionToken = errors.New("invalid session token")
+	ErrInvalidSessionKey   = errors.New("invalid session key")
+)
+
+// SessionHash is the keyed hash of a session token. Sessions are stored and looked up by it, so that the
+// stored sessions cannot be used to impersonate their users without also knowing the key.
+type SessionHash [sha256.Size]byte
+
+// String returns the hash in hexadecimal.
+func (h SessionHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// IsZero reports whether h is the zero hash, which no session has.
+func (h SessionHash) IsZero() bool {
+	return h == SessionHash{}
+}
+
+// NewSessionToken reads SessionTokenBytes bytes from rand and returns them as a bearer token, in unpadded
+// base64url.
+func NewSessionToken(rand io.Reader) (string, error) {
+	raw := make([]byte, SessionTokenBytes)
+	if _, err := io.ReadFull(rand, raw); err != nil {
+		return "", fmt.Errorf("failed to draw session token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// HashSessionToken returns the HMAC-SHA256 of the token under key.
+// It returns ErrInvalidSessionKey for an empty key, or ErrInvalidSessionToken if the token was not made by
+// NewSessionToken.
+func HashSessionToken(key []byte, token string) (SessionHash, error) {
+	if len(key) == 0 {
+		return SessionHash{}, ErrInvalidSessionKey
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != SessionTokenBytes {
+		return SessionHash{}, ErrInvalidSessionToken
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(raw)
+	var h SessionHash
+	copy(h[:], mac.Sum(nil))
+	return h, nil
+}
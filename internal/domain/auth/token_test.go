@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSessionToken(t *testing.T) {
+	t.Parallel()
+	token, err := NewSessionToken(rand.Reader)
+	require.NoError(t, err)
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	require.Len(t, raw, SessionTokenBytes)
+
+	other, err := NewSessionToken(rand.Reader)
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+
+	_, err = NewSessionToken(bytes.NewReader(make([]byte, SessionTokenBytes-1)))
+	require.Error(t, err, "a short read should fail")
+}
+
+func TestHashSessionToken(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	token := base64.RawURLEncoding.EncodeToString(bytes.Repeat([]byte{1}, SessionTokenBytes))
+	hash, err := HashSessionToken(key, token)
+	require.NoError(t, err)
+	require.False(t, hash.IsZero())
+	require.Len(t, hash.String(), 2*len(hash))
+
+	tests := []struct {
+		name    string
+		key     []byte
+		token   string
+		same    bool
+		wantErr error
+	}{
+		{name: "Same key and token", key: key, token: token, same: true},
+		{name: "Other key", key: bytes.Repeat([]byte{8}, 32), token: token},
+		{
+			name:  "Other token",
+			key:   key,
+			token: base64.RawURLEncoding.EncodeToString(bytes.Repeat([]byte{2}, SessionTokenBytes)),
+		},
+		{name: "Empty key", token: token, wantErr: ErrInvalidSessionKey},
+		{name: "Not base64url", key: key, token: "not a token!", wantErr: ErrInvalidSessionToken},
+		{name: "Padded", key: key, token: token + "=", wantErr: ErrInvalidSessionToken},
+		{
+			name:    "Too short",
+			key:     key,
+			token:   base64.RawURLEncoding.EncodeToString(bytes.Repeat([]byte{1}, SessionTokenBytes-1)),
+			wantErr: ErrInvalidSessionToken,
+		},
+		{name: "Hash as token", key: key, token: hash.String(), wantErr: ErrInvalidSessionToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := HashSessionToken(tt.key, tt.token)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.same, got == hash)
+		})
+	}
+}
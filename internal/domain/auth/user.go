@@ -2,32 +2,63 @@ package auth
 
 import (
 	"errors"
+	"math"
+	"math/big"
 )
 
 var (
 	ErrInvalidUser = errors.New("invalid user")
 )
 
+// MinSaltLength is the minimum length in bytes of the salt the secret of a user is derived with.
+const MinSaltLength = 16
+
+// KDFParams are the Argon2id cost parameters the secret of a user is derived from the password with: the
+// number of passes Time, the memory MemoryKiB in KiB and the number of lanes Threads.
+type KDFParams struct {
+	Time      uint32
+	MemoryKiB uint32
+	Threads   uint32
+}
+
+// IsValid reports whether Argon2id accepts the parameters: all of them positive and at most 255 lanes.
+func (p KDFParams) IsValid() bool {
+	return p.Time > 0 && p.MemoryKiB > 0 && p.Threads > 0 && p.Threads <= math.MaxUint8
+}
+
 type User struct {
 	userID string
-	y1     int64
-	y2     int64
+	y1     *big.Int
+	y2     *big.Int
+	salt   []byte
+	kdf    KDFParams
 }
 
 func (u User) UserID() string {
 	return u.userID
 }
 
-func (u User) Y1() int64 {
+func (u User) Y1() *big.Int {
 	return u.y1
 }
 
-func (u User) Y2() int64 {
+func (u User) Y2() *big.Int {
 	return u.y2
 }
 
-func NewUser(user string, y1 int64, y2 int64) (*User, error) {
-	u := &User{userID: user, y1: y1, y2: y2}
+// Salt returns the salt the secret x behind y1 and y2 was derived with from the password of the user.
+func (u User) Salt() []byte {
+	return u.salt
+}
+
+// KDFParams returns the Argon2id cost parameters the secret x behind y1 and y2 was derived with, so that every
+// prover derives the same x from the password whatever its own configuration.
+func (u User) KDFParams() KDFParams {
+	return u.kdf
+}
+
+func NewUser(user string, y1, y2 *big.Int, salt []byte, kdf KDFParams) (*User, error) {
+	u := &User{userID: user, y1: y1, y2: y2, salt: salt, kdf: kdf}
 	if !u.IsValid() {
 		return nil, ErrInvalidUser
 	}
@@ -35,7 +66,8 @@ func NewUser(user string, y1 int64, y2 int64) (*User, error) {
 }
 
 func (u User) IsValid() bool {
-	if u.userID == "" || u.y1 < 0 || u.y2 < 0 {
+	if u.userID == "" || u.y1 == nil || u.y2 == nil || u.y1.Sign() < 0 || u.y2.Sign() < 0 || len(u.salt) < MinSaltLength ||
+		!u.kdf.IsValid() {
 		return false
 	}
 	return true
@@ -1,16 +1,24 @@
 package auth
 
 import (
+	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
+var testSalt = bytes.Repeat([]byte{0x5a}, MinSaltLength)
+
+var testKDF = KDFParams{Time: 1, MemoryKiB: 64, Threads: 1}
+
 func TestNewUser(t *testing.T) {
 	type args struct {
 		user string
-		y1   int64
-		y2   int64
+		y1   *big.Int
+		y2   *big.Int
+		salt []byte
+		kdf  KDFParams
 	}
 	tests := []struct {
 		name    string
@@ -19,29 +27,50 @@ func TestNewUser(t *testing.T) {
 	}{
 		{
 			name:    "Valid Case",
-			args:    args{user: "valid_user", y1: 10, y2: 20},
+			args:    args{user: "valid_user", y1: big.NewInt(10), y2: big.NewInt(20), salt: testSalt, kdf: testKDF},
 			wantErr: false,
 		},
 		{
 			name:    "Empty username",
-			args:    args{user: "", y1: 10, y2: 20},
+			args:    args{user: "", y1: big.NewInt(10), y2: big.NewInt(20), salt: testSalt, kdf: testKDF},
 			wantErr: true,
 		},
 		{
 			name:    "Negative y1",
-			args:    args{user: "valid_user", y1: -10, y2: 20},
+			args:    args{user: "valid_user", y1: big.NewInt(-10), y2: big.NewInt(20), salt: testSalt, kdf: testKDF},
 			wantErr: true,
 		},
 		{
 			name:    "Negative y2",
-			args:    args{user: "valid_user", y1: 10, y2: -20},
+			args:    args{user: "valid_user", y1: big.NewInt(10), y2: big.NewInt(-20), salt: testSalt, kdf: testKDF},
+			wantErr: true,
+		},
+		{
+			name:    "Short salt",
+			args:    args{user: "valid_user", y1: big.NewInt(10), y2: big.NewInt(20), salt: testSalt[:MinSaltLength-1], kdf: testKDF},
+			wantErr: true,
+		},
+		{
+			name:    "Missing KDF parameters",
+			args:    args{user: "valid_user", y1: big.NewInt(10), y2: big.NewInt(20), salt: testSalt},
+			wantErr: true,
+		},
+		{
+			name: "Too many threads",
+			args: args{user: "valid_user", y1: big.NewInt(10), y2: big.NewInt(20), salt: testSalt,
+				kdf: KDFParams{Time: 1, MemoryKiB: 64, Threads: 256}},
+			wantErr: true,
+		},
+		{
+			name:    "Nil y1",
+			args:    args{user: "valid_user", y1: nil, y2: big.NewInt(20), salt: testSalt, kdf: testKDF},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			_, err := NewUser(tt.args.user, tt.args.y1, tt.args.y2)
+			_, err := NewUser(tt.args.user, tt.args.y1, tt.args.y2, tt.args.salt, tt.args.kdf)
 			if tt.wantErr {
 				require.Error(t, err, "NewUser() error = %v, wantErr %v", err, tt.wantErr)
 			} else {
@@ -59,32 +88,37 @@ func TestUser_IsValid(t *testing.T) {
 	}{
 		{
 			name: "Valid Case",
-			user: User{userID: "valid_user", y1: 10, y2: 20},
+			user: User{userID: "valid_user", y1: big.NewInt(10), y2: big.NewInt(20), salt: testSalt, kdf: testKDF},
 			want: true,
 		},
 		{
 			name: "Empty Username",
-			user: User{userID: "", y1: 10, y2: 20},
+			user: User{userID: "", y1: big.NewInt(10), y2: big.NewInt(20), salt: testSalt, kdf: testKDF},
 			want: false,
 		},
 		{
 			name: "Negative y1",
-			user: User{userID: "valid_user", y1: -10, y2: 20},
+			user: User{userID: "valid_user", y1: big.NewInt(-10), y2: big.NewInt(20), salt: testSalt, kdf: testKDF},
 			want: false,
 		},
 		{
 			name: "Negative y2",
-			user: User{userID: "valid_user", y1: 10, y2: -20},
+			user: User{userID: "valid_user", y1: big.NewInt(10), y2: big.NewInt(-20), salt: testSalt, kdf: testKDF},
+			want: false,
+		},
+		{
+			name: "Missing salt",
+			user: User{userID: "valid_user", y1: big.NewInt(10), y2: big.NewInt(20), kdf: testKDF},
 			want: false,
 		},
 		{
 			name: "Negative y1 and y2",
-			user: User{userID: "valid_user", y1: -10, y2: -20},
+			user: User{userID: "valid_user", y1: big.NewInt(-10), y2: big.NewInt(-20), salt: testSalt, kdf: testKDF},
 			want: false,
 		},
 		{
 			name: "Empty username and negative y1/y2",
-			user: User{userID: "", y1: -10, y2: -20},
+			user: User{userID: "", y1: big.NewInt(-10), y2: big.NewInt(-20), salt: testSalt, kdf: testKDF},
 			want: false,
 		},
 	}
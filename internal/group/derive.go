@@ -0,0 +1,76 @@
+package group
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// GeneratorHDomain is the default domain-separation string for deriving the second generator h.
+const GeneratorHDomain = "n-zkp-test/generator-h/v1"
+
+// Transcript records every input and the output of a generator derivation, so that an auditor
+// can reproduce it independently and convince themselves that nobody chose h.
+type Transcript struct {
+	Domain      string
+	Description string
+	G           []byte
+	Message     []byte
+	Method      string
+	H           []byte
+}
+
+// String renders the transcript one field per line, with byte strings in hexadecimal.
+func (t Transcript) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "domain:      %q\n", t.Domain)
+	fmt.Fprintf(&b, "group:       %s\n", t.Description)
+	fmt.Fprintf(&b, "g:           %s\n", hex.EncodeToString(t.G))
+	fmt.Fprintf(&b, "message:     %s\n", hex.EncodeToString(t.Message))
+	fmt.Fprintf(&b, "method:      %s\n", t.Method)
+	fmt.Fprintf(&b, "h:           %s\n", hex.EncodeToString(t.H))
+	return b.String()
+}
+
+// DeriveH deterministically derives a second generator h from the generator g, the group
+// description and a domain-separation string by hashing them to the group. Because h is the
+// output of a hash, nobody knows log_g(h). The message hashed under the domain is the group
+// description followed by the encoding of g, each prefixed with its 4-byte big-endian length.
+func DeriveH(grp Group, g Element, domain string) (Element, Transcript) {
+	description := grp.Description()
+	gEnc := grp.Encode(g)
+
+	msg := appendFramed(nil, []byte(description))
+	msg = appendFramed(msg, gEnc)
+
+	h := grp.HashToElement([]byte(domain), msg)
+
+	return h, Transcript{
+		Domain:      domain,
+		Description: description,
+		G:           gEnc,
+		Message:     msg,
+		Method:      hashToElementMethod(grp),
+		H:           grp.Encode(h),
+	}
+}
+
+// appendFramed appends b to dst prefixed with its length as a 4-byte big-endian integer.
+func appendFramed(dst, b []byte) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(b)))
+	return append(dst, b...)
+}
+
+// hashToElementMethod describes the HashToElement construction of grp for transcripts.
+func hashToElementMethod(grp Group) string {
+	switch grp.(type) {
+	case *MODP:
+		return "t = SHA-256(len(domain) || domain || message || counter || block) for block = 0, 1, ... " +
+			"truncated to len(p)+16 bytes; h = (t mod p)^((p-1)/q) mod p with the first counter giving h > 1"
+	case *Ristretto255:
+		return "h = ristretto255 one-way map of SHA-512(len(domain) || domain || message)"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,29 @@
+package group
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveH(t *testing.T) {
+	for _, grp := range testGroups(t) {
+		t.Run(grp.Name(), func(t *testing.T) {
+			t.Parallel()
+			g := grp.Generator()
+			h, transcript := DeriveH(grp, g, GeneratorHDomain)
+
+			require.False(t, h.Equal(g), "h should differ from g")
+			require.Equal(t, grp.Encode(h), transcript.H)
+			require.Equal(t, grp.Encode(g), transcript.G)
+			require.Equal(t, grp.Description(), transcript.Description)
+
+			msg := appendFramed(appendFramed(nil, []byte(grp.Description())), grp.Encode(g))
+			require.Equal(t, msg, transcript.Message, "message should frame the description and g")
+			require.True(t, grp.HashToElement([]byte(GeneratorHDomain), msg).Equal(h), "h should be reproducible from the transcript")
+
+			require.Contains(t, transcript.String(), hex.EncodeToString(transcript.H))
+		})
+	}
+}
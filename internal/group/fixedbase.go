@@ -0,0 +1,62 @@
+package group
+
+import (
+	"math/big"
+)
+
+// FixedBase is a precomputed table of powers of a base that never changes, such as a generator. It
+// trades memory for speed: with a window of w bits it stores base^(j·2^(w·i)) for every non-zero
+// w-bit digit j and every window i of the group order, so an exponentiation only needs one group
+// multiplication per non-zero digit of the scalar and no squarings at all.
+type FixedBase struct {
+	grp   Group
+	base  Element
+	w     uint
+	table [][]Element
+}
+
+// NewFixedBase builds the table for base in grp with windows of w bits, which takes about
+// 2^w·bits(q)/w group multiplications and as many stored elements. It panics if w is zero.
+func NewFixedBase(grp Group, base Element, w uint) *FixedBase {
+	if w == 0 {
+		panic("group: fixed-base window must be positive")
+	}
+	windows := (grp.Order().BitLen() + int(w) - 1) / int(w)
+	digits := 1<<w - 1
+
+	table := make([][]Element, windows)
+	b := base
+	for i := range table {
+		row := make([]Element, digits)
+		row[0] = b
+		for j := 1; j < digits; j++ {
+			row[j] = grp.Mul(row[j-1], b)
+		}
+		table[i] = row
+		// base^(2^(w·(i+1))) = base^((2^w - 1)·2^(w·i)) · base^(2^(w·i)).
+		b = grp.Mul(row[digits-1], b)
+	}
+	return &FixedBase{grp: grp, base: base, w: w, table: table}
+}
+
+// Base returns the element the table was built for.
+func (f *FixedBase) Base() Element {
+	return f.base
+}
+
+// Exp returns base^k, reducing k modulo the group order first. Like Group.Exp it does not run in
+// constant time.
+func (f *FixedBase) Exp(k *big.Int) Element {
+	e := new(big.Int).Mod(k, f.grp.Order())
+	acc := f.grp.Identity()
+	for i, row := range f.table {
+		var digit uint
+		for bit := uint(0); bit < f.w; bit++ {
+			digit |= e.Bit(i*int(f.w)+int(bit)) << bit
+		}
+		if digit != 0 {
+			acc = f.grp.Mul(acc, row[digit-1])
+		}
+	}
+	return acc
+}
@@ -0,0 +1,42 @@
+package group
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+// benchWindow is the window width of the fixed-base tables in the benchmarks, the default of the
+// configuration.
+const benchWindow = 4
+
+// BenchmarkFixedBase compares g^k computed with Exp against a lookup in a fixed-base table of
+// benchWindow bits, for every backend. The cost of building the table is reported separately.
+func BenchmarkFixedBase(b *testing.B) {
+	for _, grp := range testGroups(b) {
+		b.Run(grp.Name(), func(b *testing.B) {
+			k, err := grp.RandomScalar(rand.Reader)
+			if err != nil {
+				b.Fatal(err)
+			}
+			g := grp.Generator()
+
+			b.Run("exp", func(b *testing.B) {
+				for range b.N {
+					grp.Exp(g, k)
+				}
+			})
+			b.Run("fixedbase", func(b *testing.B) {
+				fb := NewFixedBase(grp, g, benchWindow)
+				b.ResetTimer()
+				for range b.N {
+					fb.Exp(k)
+				}
+			})
+			b.Run("build", func(b *testing.B) {
+				for range b.N {
+					NewFixedBase(grp, g, benchWindow)
+				}
+			})
+		})
+	}
+}
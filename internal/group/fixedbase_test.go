@@ -0,0 +1,44 @@
+package group
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedBase_Exp(t *testing.T) {
+	groups := append(testGroups(t),
+		NewMODP("safe", big.NewInt(23), big.NewInt(11), big.NewInt(4)),
+		NewMODP("unsafe", big.NewInt(31), big.NewInt(5), big.NewInt(2)))
+	for _, grp := range groups {
+		t.Run(grp.Name(), func(t *testing.T) {
+			t.Parallel()
+			k, err := grp.RandomScalar(rand.Reader)
+			require.NoError(t, err)
+			base := grp.Exp(grp.Generator(), k)
+
+			scalars := []*big.Int{
+				big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(-7),
+				new(big.Int).Sub(grp.Order(), big.NewInt(1)),
+				new(big.Int).Add(grp.Order(), big.NewInt(5)),
+				new(big.Int).Lsh(k, 64),
+			}
+			for range 5 {
+				r, err := grp.RandomScalar(rand.Reader)
+				require.NoError(t, err)
+				scalars = append(scalars, r)
+			}
+
+			for _, w := range []uint{1, 3, 4, 7} {
+				fb := NewFixedBase(grp, base, w)
+				require.True(t, fb.Base().Equal(base))
+				for _, s := range scalars {
+					require.True(t, fb.Exp(s).Equal(grp.Exp(base, s)), "w=%d: base^%v should match Exp", w, s)
+				}
+			}
+			require.Panics(t, func() { NewFixedBase(grp, base, 0) }, "a zero window should panic")
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package group abstracts the prime-order groups the Chaum-Pedersen protocol runs in.
+// Elements are opaque values that are only meaningful to the Group that produced them;
+// scalars are *big.Int values interpreted modulo the group order.
+package group
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// ErrInvalidElement is returned when bytes do not decode to an element of the group.
+// ErrOrderTooSmall is returned when the group order leaves no room for a non-zero scalar.
+var (
+	ErrInvalidElement = errors.New("invalid group element")
+	ErrOrderTooSmall  = errors.New("group order must be greater than 1")
+)
+
+// Element is a member of a Group.
+type Element interface {
+	// Equal reports whether the element and other represent the same group element.
+	Equal(other Element) bool
+}
+
+// Group is a cyclic group of prime order written multiplicatively: Exp is exponentiation
+// (scalar multiplication on a curve) and Mul is the group operation (point addition).
+type Group interface {
+	// Name identifies the group.
+	Name() string
+	// Description canonically describes the group parameters, independently of its name.
+	Description() string
+	// Order returns the prime order q of the group.
+	Order() *big.Int
+	// Generator returns the canonical generator of the group.
+	Generator() Element
+	// Identity returns the neutral element of the group.
+	Identity() Element
+	// Exp returns base raised to the power of scalar.
+	Exp(base Element, scalar *big.Int) Element
+	// Mul returns the product a·b.
+	Mul(a, b Element) Element
+	// MultiExp returns the product of bases[i]^scalars[i], computed in one pass. It runs in
+	// variable time and must only be used with public scalars, such as when verifying a proof.
+	MultiExp(bases []Element, scalars []*big.Int) Element
+	// Encode returns the canonical fixed-length encoding of e.
+	Encode(e Element) []byte
+	// Decode parses an encoding produced by Encode. Leading zero bytes may be omitted.
+	// It returns ErrInvalidElement if b does not encode an element of the prime-order group.
+	Decode(b []byte) (Element, error)
+	// RandomScalar returns a uniformly random scalar in [1, Order()).
+	RandomScalar(rand io.Reader) (*big.Int, error)
+	// HashToElement deterministically maps msg to an element whose discrete logarithm
+	// to any other base is unknown. The domain separates unrelated uses of the map and
+	// is hashed with a 4-byte big-endian length prefix.
+	HashToElement(domain, msg []byte) Element
+}
+
+// randomScalar returns a uniformly random scalar in [1, q) read from r,
+// or from crypto/rand when r is nil.
+func randomScalar(r io.Reader, q *big.Int) (*big.Int, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	upper := new(big.Int).Sub(q, big.NewInt(1))
+	if upper.Sign() <= 0 {
+		return nil, ErrOrderTooSmall
+	}
+	k, err := rand.Int(r, upper)
+	if err != nil {
+		return nil, err
+	}
+	return k.Add(k, big.NewInt(1)), nil
+}
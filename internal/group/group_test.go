@@ -0,0 +1,158 @@
+package group
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testGroups returns one instance of every backend: the RFC 3526 2048-bit MODP group and ristretto255.
+func testGroups(tb testing.TB) []Group {
+	tb.Helper()
+	p, ok := new(big.Int).SetString("FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74"+
+		"020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F1437"+
+		"4FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED"+
+		"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF05"+
+		"98DA48361C55D39A69163FA8FD24CF5F83655D23DCA3AD961C62F356208552BB"+
+		"9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B"+
+		"E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF695581718"+
+		"3995497CEA956AE515D2261898FA051015728E5A8AACAA68FFFFFFFFFFFFFFFF", 16)
+	require.True(tb, ok)
+	return []Group{
+		NewMODP("modp-2048", p, new(big.Int).Rsh(p, 1), big.NewInt(2)),
+		NewRistretto255(),
+	}
+}
+
+func TestGroup_ExpMul(t *testing.T) {
+	for _, grp := range testGroups(t) {
+		t.Run(grp.Name(), func(t *testing.T) {
+			t.Parallel()
+			a, err := grp.RandomScalar(rand.Reader)
+			require.NoError(t, err)
+			b, err := grp.RandomScalar(rand.Reader)
+			require.NoError(t, err)
+
+			g := grp.Generator()
+			sum := new(big.Int).Add(a, b)
+			require.True(t, grp.Mul(grp.Exp(g, a), grp.Exp(g, b)).Equal(grp.Exp(g, sum)), "g^a * g^b should equal g^(a+b)")
+			require.True(t, grp.Exp(g, grp.Order()).Equal(grp.Identity()), "g^q should be the identity")
+			require.True(t, grp.Exp(g, big.NewInt(0)).Equal(grp.Identity()), "g^0 should be the identity")
+			require.True(t, grp.Mul(g, grp.Identity()).Equal(g), "g * 1 should equal g")
+			require.False(t, grp.Exp(g, a).Equal(grp.Exp(g, b)), "distinct scalars should give distinct elements")
+		})
+	}
+}
+
+func TestGroup_EncodeDecode(t *testing.T) {
+	for _, grp := range testGroups(t) {
+		t.Run(grp.Name(), func(t *testing.T) {
+			t.Parallel()
+			k, err := grp.RandomScalar(rand.Reader)
+			require.NoError(t, err)
+			e := grp.Exp(grp.Generator(), k)
+
+			enc := grp.Encode(e)
+			got, err := grp.Decode(enc)
+			require.NoError(t, err)
+			require.True(t, e.Equal(got), "decoded element should equal the original")
+
+			// Elements travel as big-endian integers, which drop leading zero bytes.
+			got, err = grp.Decode(new(big.Int).SetBytes(enc).Bytes())
+			require.NoError(t, err)
+			require.True(t, e.Equal(got), "decoding should tolerate stripped leading zeros")
+		})
+	}
+}
+
+func TestGroup_HashToElement(t *testing.T) {
+	for _, grp := range testGroups(t) {
+		t.Run(grp.Name(), func(t *testing.T) {
+			t.Parallel()
+			h1 := grp.HashToElement([]byte("domain"), []byte("msg"))
+			h2 := grp.HashToElement([]byte("domain"), []byte("msg"))
+			h3 := grp.HashToElement([]byte("other"), []byte("msg"))
+			require.True(t, h1.Equal(h2), "hashing should be deterministic")
+			require.False(t, h1.Equal(h3), "hashing should depend on the domain")
+			require.True(t, grp.Exp(h1, grp.Order()).Equal(grp.Exp(h1, big.NewInt(0))), "h^q should be the identity")
+		})
+	}
+}
+
+func TestGroup_RandomScalar(t *testing.T) {
+	for _, grp := range testGroups(t) {
+		t.Run(grp.Name(), func(t *testing.T) {
+			t.Parallel()
+			for range 100 {
+				k, err := grp.RandomScalar(nil)
+				require.NoError(t, err)
+				require.Positive(t, k.Sign())
+				require.Negative(t, k.Cmp(grp.Order()))
+			}
+		})
+	}
+}
+
+func TestRandomScalar_OrderTooSmall(t *testing.T) {
+	_, err := NewMODP("toy", big.NewInt(3), big.NewInt(1), big.NewInt(2)).RandomScalar(nil)
+	require.ErrorIs(t, err, ErrOrderTooSmall)
+}
+
+func TestMODP_DecodeMembership(t *testing.T) {
+	// 23 = 2*11+1 is a safe prime, checked with the Jacobi symbol; the order-5 subgroup of Z_31^* has cofactor 6.
+	safe := NewMODP("safe", big.NewInt(23), big.NewInt(11), big.NewInt(4))
+	unsafe := NewMODP("unsafe", big.NewInt(31), big.NewInt(5), big.NewInt(2))
+
+	tests := []struct {
+		name    string
+		grp     *MODP
+		in      []byte
+		wantErr bool
+	}{
+		{name: "safe: generator", grp: safe, in: []byte{4}},
+		{name: "safe: identity", grp: safe, in: []byte{1}},
+		{name: "safe: zero", grp: safe, in: []byte{0}, wantErr: true},
+		{name: "safe: empty", grp: safe, in: nil, wantErr: true},
+		{name: "safe: p", grp: safe, in: []byte{23}, wantErr: true},
+		{name: "safe: above p", grp: safe, in: []byte{27}, wantErr: true},
+		{name: "safe: order 2", grp: safe, in: []byte{22}, wantErr: true},
+		{name: "safe: non-residue", grp: safe, in: []byte{5}, wantErr: true},
+		{name: "safe: too long", grp: safe, in: []byte{0, 4}, wantErr: true},
+		{name: "unsafe: member", grp: unsafe, in: []byte{16}},
+		{name: "unsafe: non-member", grp: unsafe, in: []byte{3}, wantErr: true},
+		{name: "unsafe: order 2", grp: unsafe, in: []byte{30}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tt.grp.Decode(tt.in)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidElement)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRistretto255_DecodeInvalid(t *testing.T) {
+	grp := NewRistretto255()
+	tests := []struct {
+		name string
+		in   []byte
+	}{
+		{name: "too long", in: make([]byte, 33)},
+		{name: "non-canonical", in: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}},
+		{name: "not a point", in: []byte{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := grp.Decode(tt.in)
+			require.ErrorIs(t, err, ErrInvalidElement)
+		})
+	}
+}
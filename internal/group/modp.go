@@ -0,0 +1,146 @@
+package group
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// MODP is the subgroup of prime order q of the multiplicative group of integers modulo the prime p.
+type MODP struct {
+	name     string
+	p        *big.Int
+	q        *big.Int
+	g        *big.Int
+	cofactor *big.Int
+	safe     bool
+	size     int
+}
+
+type modpElement struct {
+	v *big.Int
+}
+
+// Equal reports whether e and other are the same residue.
+func (e modpElement) Equal(other Element) bool {
+	o, ok := other.(modpElement)
+	return ok && e.v.Cmp(o.v) == 0
+}
+
+// modpValue returns the residue held by e. It panics if e does not come from a MODP group.
+func modpValue(e Element) *big.Int {
+	me, ok := e.(modpElement)
+	if !ok {
+		panic("group: element does not belong to a MODP group")
+	}
+	return me.v
+}
+
+// NewMODP returns the order-q subgroup of Z_p^* with canonical generator g.
+// The parameters are not validated: p and q must be non-zero.
+func NewMODP(name string, p, q, g *big.Int) *MODP {
+	cofactor := new(big.Int).Sub(p, big.NewInt(1))
+	cofactor.Div(cofactor, q)
+	safe := new(big.Int).Lsh(q, 1)
+	return &MODP{
+		name:     name,
+		p:        p,
+		q:        q,
+		g:        g,
+		cofactor: cofactor,
+		safe:     safe.Add(safe, big.NewInt(1)).Cmp(p) == 0,
+		size:     (p.BitLen() + 7) / 8,
+	}
+}
+
+func (m *MODP) Name() string {
+	return m.name
+}
+
+func (m *MODP) Order() *big.Int {
+	return m.q
+}
+
+// Description returns "modp p=<hex> q=<hex>".
+func (m *MODP) Description() string {
+	return fmt.Sprintf("modp p=%x q=%x", m.p, m.q)
+}
+
+// Modulus returns the prime p.
+func (m *MODP) Modulus() *big.Int {
+	return m.p
+}
+
+func (m *MODP) Generator() Element {
+	return modpElement{v: m.g}
+}
+
+func (m *MODP) Identity() Element {
+	return modpElement{v: big.NewInt(1)}
+}
+
+func (m *MODP) Exp(base Element, scalar *big.Int) Element {
+	return modpElement{v: new(big.Int).Exp(modpValue(base), scalar, m.p)}
+}
+
+func (m *MODP) Mul(a, b Element) Element {
+	v := new(big.Int).Mul(modpValue(a), modpValue(b))
+	return modpElement{v: v.Mod(v, m.p)}
+}
+
+// Encode returns the residue as a big-endian integer padded to the byte length of p.
+func (m *MODP) Encode(e Element) []byte {
+	return modpValue(e).FillBytes(make([]byte, m.size))
+}
+
+// Decode interprets b as a big-endian integer and checks that it is an element of the order-q subgroup:
+// it must lie in [1, p) and satisfy v^q = 1 mod p. When p = 2q+1 is a safe prime the subgroup is the set
+// of quadratic residues, which the much cheaper Jacobi symbol decides instead.
+func (m *MODP) Decode(b []byte) (Element, error) {
+	if len(b) > m.size {
+		return nil, ErrInvalidElement
+	}
+	v := new(big.Int).SetBytes(b)
+	if v.Sign() == 0 || v.Cmp(m.p) >= 0 || !m.inSubgroup(v) {
+		return nil, ErrInvalidElement
+	}
+	return modpElement{v: v}, nil
+}
+
+// inSubgroup reports whether v, which must lie in [1, p), belongs to the order-q subgroup.
+func (m *MODP) inSubgroup(v *big.Int) bool {
+	if m.safe {
+		return big.Jacobi(v, m.p) == 1
+	}
+	return new(big.Int).Exp(v, m.q, m.p).Cmp(big.NewInt(1)) == 0
+}
+
+func (m *MODP) RandomScalar(rand io.Reader) (*big.Int, error) {
+	return randomScalar(rand, m.q)
+}
+
+// HashToElement expands the length-prefixed domain, msg and a counter with SHA-256 in counter mode to the byte length
+// of p plus 16 bytes, reduces the result mod p and raises it to the cofactor (p-1)/q to land in the
+// order-q subgroup. A candidate equal to the identity is discarded by incrementing the counter.
+func (m *MODP) HashToElement(domain, msg []byte) Element {
+	size := m.size + 16
+	for counter := uint32(0); ; counter++ {
+		buf := make([]byte, 0, size+sha256.Size)
+		for block := uint32(0); len(buf) < size; block++ {
+			hash := sha256.New()
+			hash.Write(appendFramed(nil, domain))
+			hash.Write(msg)
+			hash.Write(binary.BigEndian.AppendUint32(nil, counter))
+			hash.Write(binary.BigEndian.AppendUint32(nil, block))
+			buf = hash.Sum(buf)
+		}
+		t := new(big.Int).SetBytes(buf[:size])
+		t.Mod(t, m.p)
+		h := t.Exp(t, m.cofactor, m.p)
+		if h.Cmp(big.NewInt(1)) > 0 {
+			return modpElement{v: h}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package group
+
+import (
+	"math/big"
+)
+
+// maxStrausWindow bounds the window width tried by strausWindow; wider windows only pay off for
+// exponents far longer than any supported group order.
+const maxStrausWindow = 8
+
+// MultiExp returns the product of bases[i]^scalars[i] mod p with Straus' interleaved sliding-window
+// method: all exponents share a single chain of squarings, and each base contributes one multiplication
+// by a precomputed odd power per window of its exponent. For two bases this costs about as many
+// modular multiplications as a single exponentiation. Scalars are reduced mod q first.
+// It runs in variable time and must only be used with public scalars. It panics if bases and scalars
+// differ in length.
+func (m *MODP) MultiExp(bases []Element, scalars []*big.Int) Element {
+	if len(bases) != len(scalars) {
+		panic("group: MultiExp needs as many scalars as bases")
+	}
+
+	exps := make([]*big.Int, len(scalars))
+	bits := 0
+	for i, k := range scalars {
+		exps[i] = new(big.Int).Mod(k, m.q)
+		bits = max(bits, exps[i].BitLen())
+	}
+	w := strausWindow(bits)
+
+	// prod and quo are reused across multiplications to avoid allocating on every step.
+	prod, quo := new(big.Int), new(big.Int)
+	mulMod := func(z, x, y *big.Int) {
+		prod.Mul(x, y)
+		quo.QuoRem(prod, m.p, z)
+	}
+
+	tables := make([][]*big.Int, len(bases))
+	digits := make([][]uint, len(bases))
+	for i, b := range bases {
+		tables[i] = oddPowers(modpValue(b), w, mulMod)
+		digits[i] = slidingWindowDigits(exps[i], w)
+	}
+
+	acc := big.NewInt(1)
+	started := false
+	for pos := bits - 1; pos >= 0; pos-- {
+		if started {
+			mulMod(acc, acc, acc)
+		}
+		for i := range bases {
+			if pos >= len(digits[i]) || digits[i][pos] == 0 {
+				continue
+			}
+			t := tables[i][digits[i][pos]>>1]
+			if !started {
+				acc.Set(t)
+				started = true
+				continue
+			}
+			mulMod(acc, acc, t)
+		}
+	}
+	return modpElement{v: acc}
+}
+
+// strausWindow returns the window width minimising the multiplications spent per base on an exponent of
+// the given bit length: 2^(w-1) to build the table of odd powers plus about bits/(w+1) while scanning.
+func strausWindow(bits int) uint {
+	best, bestCost := uint(1), bits
+	for w := uint(2); w <= maxStrausWindow; w++ {
+		if cost := 1<<(w-1) + bits/int(w+1); cost < bestCost {
+			best, bestCost = w, cost
+		}
+	}
+	return best
+}
+
+// oddPowers returns b^1, b^3, ..., b^(2^w - 1), the table a sliding window of width w indexes into.
+func oddPowers(b *big.Int, w uint, mulMod func(z, x, y *big.Int)) []*big.Int {
+	table := make([]*big.Int, 1<<(w-1))
+	table[0] = new(big.Int).Set(b)
+	if len(table) == 1 {
+		return table
+	}
+	sq := new(big.Int)
+	mulMod(sq, b, b)
+	for j := 1; j < len(table); j++ {
+		table[j] = new(big.Int)
+		mulMod(table[j], table[j-1], sq)
+	}
+	return table
+}
+
+// slidingWindowDigits recodes e into windows of at most w bits that start and end with a one bit. The
+// result has one entry per bit of e: the odd value of the window ending at that bit, or zero.
+func slidingWindowDigits(e *big.Int, w uint) []uint {
+	digits := make([]uint, e.BitLen())
+	for pos := len(digits) - 1; pos >= 0; {
+		if e.Bit(pos) == 0 {
+			pos--
+			continue
+		}
+		low := max(pos-int(w)+1, 0)
+		for e.Bit(low) == 0 {
+			low++
+		}
+		var v uint
+		for k := pos; k >= low; k-- {
+			v = v<<1 | e.Bit(k)
+		}
+		digits[low] = v
+		pos = low - 1
+	}
+	return digits
+}
@@ -0,0 +1,37 @@
+package group
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// BenchmarkMultiExp compares computing g^a·y^b with two exponentiations and a multiplication
+// against a single MultiExp, for every backend.
+func BenchmarkMultiExp(b *testing.B) {
+	for _, grp := range testGroups(b) {
+		b.Run(grp.Name(), func(b *testing.B) {
+			scalars := make([]*big.Int, 3)
+			var err error
+			for i := range scalars {
+				if scalars[i], err = grp.RandomScalar(rand.Reader); err != nil {
+					b.Fatal(err)
+				}
+			}
+			g := grp.Generator()
+			y := grp.Exp(g, scalars[2])
+
+			b.Run("separate", func(b *testing.B) {
+				for range b.N {
+					grp.Mul(grp.Exp(g, scalars[0]), grp.Exp(y, scalars[1]))
+				}
+			})
+			b.Run("multiexp", func(b *testing.B) {
+				bases := []Element{g, y}
+				for range b.N {
+					grp.MultiExp(bases, scalars[:2])
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package group
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_MultiExp(t *testing.T) {
+	groups := append(testGroups(t),
+		NewMODP("safe", big.NewInt(23), big.NewInt(11), big.NewInt(4)),
+		NewMODP("unsafe", big.NewInt(31), big.NewInt(5), big.NewInt(2)))
+	for _, grp := range groups {
+		t.Run(grp.Name(), func(t *testing.T) {
+			t.Parallel()
+			random := func() *big.Int {
+				k, err := grp.RandomScalar(rand.Reader)
+				require.NoError(t, err)
+				return k
+			}
+			g := grp.Generator()
+			bases := []Element{g, grp.Exp(g, random()), grp.Exp(g, random())}
+
+			tests := []struct {
+				name    string
+				scalars []*big.Int
+			}{
+				{name: "random", scalars: []*big.Int{random(), random(), random()}},
+				{name: "zero scalars", scalars: []*big.Int{big.NewInt(0), random(), big.NewInt(0)}},
+				{name: "all zero", scalars: []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)}},
+				{name: "small scalars", scalars: []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+				{name: "negative and oversized scalars", scalars: []*big.Int{
+					big.NewInt(-7), new(big.Int).Add(grp.Order(), big.NewInt(5)), new(big.Int).Lsh(random(), 64),
+				}},
+			}
+			for _, tt := range tests {
+				want := grp.Identity()
+				for i, k := range tt.scalars {
+					want = grp.Mul(want, grp.Exp(bases[i], k))
+				}
+				require.True(t, grp.MultiExp(bases, tt.scalars).Equal(want), "%s: MultiExp should equal the product of exponentiations", tt.name)
+				require.True(t, grp.MultiExp(bases[:1], tt.scalars[:1]).Equal(grp.Exp(bases[0], tt.scalars[0])), "%s: single base", tt.name)
+			}
+			require.True(t, grp.MultiExp(nil, nil).Equal(grp.Identity()), "empty product should be the identity")
+			require.Panics(t, func() { grp.MultiExp(bases, nil) }, "mismatched lengths should panic")
+		})
+	}
+}
+
+func Test_slidingWindowDigits(t *testing.T) {
+	for _, w := range []uint{1, 3, 5, maxStrausWindow} {
+		for range 20 {
+			e, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 300))
+			require.NoError(t, err)
+			digits := slidingWindowDigits(e, w)
+			got := new(big.Int)
+			for pos, d := range digits {
+				require.Less(t, d, uint(1)<<w, "digit should fit the window")
+				if d != 0 {
+					require.Equal(t, uint(1), d&1, "digits should be odd")
+					got.Add(got, new(big.Int).Lsh(new(big.Int).SetUint64(uint64(d)), uint(pos)))
+				}
+			}
+			require.Equal(t, e, got, "digits should recompose the exponent")
+		}
+	}
+}
+
+func Test_strausWindow(t *testing.T) {
+	require.Equal(t, uint(1), strausWindow(0))
+	prev := strausWindow(1)
+	for _, bits := range []int{16, 64, 256, 1024, 2048, 3072, 4096} {
+		w := strausWindow(bits)
+		require.GreaterOrEqual(t, w, prev, "window should grow with the exponent length")
+		prev = w
+	}
+}
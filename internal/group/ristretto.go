@@ -0,0 +1,135 @@
+package group
+
+import (
+	"crypto/sha512"
+	"io"
+	"math/big"
+	"slices"
+
+	"github.com/gtank/ristretto255"
+)
+
+// Ristretto255Name is the name of the ristretto255 group.
+const Ristretto255Name = "ristretto255"
+
+const ristrettoSize = 32
+
+// ristrettoOrder is the prime order l = 2^252 + 27742317777372353535851937790883648493 of ristretto255.
+var ristrettoOrder, _ = new(big.Int).SetString(
+	"7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
+// Ristretto255 is the prime-order group built on Curve25519 by the ristretto encoding
+// (RFC 9496). Encodings are 32 bytes and every valid encoding is a group element.
+type Ristretto255 struct{}
+
+type ristrettoElement struct {
+	e *ristretto255.Element
+}
+
+// Equal reports whether e and other are the same point.
+func (e ristrettoElement) Equal(other Element) bool {
+	o, ok := other.(ristrettoElement)
+	return ok && e.e.Equal(o.e) == 1
+}
+
+// ristrettoPoint returns the point held by e. It panics if e does not come from Ristretto255.
+func ristrettoPoint(e Element) *ristretto255.Element {
+	re, ok := e.(ristrettoElement)
+	if !ok {
+		panic("group: element does not belong to ristretto255")
+	}
+	return re.e
+}
+
+// ristrettoScalar converts a scalar to its little-endian ristretto255 representation after
+// reducing it modulo the group order.
+func ristrettoScalar(k *big.Int) *ristretto255.Scalar {
+	buf := new(big.Int).Mod(k, ristrettoOrder).FillBytes(make([]byte, ristrettoSize))
+	slices.Reverse(buf)
+	s := ristretto255.NewScalar()
+	if err := s.Decode(buf); err != nil {
+		panic("group: reduced scalar is not canonical: " + err.Error())
+	}
+	return s
+}
+
+// NewRistretto255 returns the ristretto255 group.
+func NewRistretto255() *Ristretto255 {
+	return &Ristretto255{}
+}
+
+func (r *Ristretto255) Name() string {
+	return Ristretto255Name
+}
+
+// Description returns the group name: ristretto255 has no parameters.
+func (r *Ristretto255) Description() string {
+	return Ristretto255Name
+}
+
+func (r *Ristretto255) Order() *big.Int {
+	return ristrettoOrder
+}
+
+func (r *Ristretto255) Generator() Element {
+	return ristrettoElement{e: ristretto255.NewElement().Base()}
+}
+
+func (r *Ristretto255) Identity() Element {
+	return ristrettoElement{e: ristretto255.NewElement().Zero()}
+}
+
+func (r *Ristretto255) Exp(base Element, scalar *big.Int) Element {
+	return ristrettoElement{e: ristretto255.NewElement().ScalarMult(ristrettoScalar(scalar), ristrettoPoint(base))}
+}
+
+func (r *Ristretto255) Mul(a, b Element) Element {
+	return ristrettoElement{e: ristretto255.NewElement().Add(ristrettoPoint(a), ristrettoPoint(b))}
+}
+
+// MultiExp returns the sum of scalars[i]·bases[i] using the variable-time multi-scalar multiplication of the
+// ristretto255 package. It panics if bases and scalars differ in length.
+func (r *Ristretto255) MultiExp(bases []Element, scalars []*big.Int) Element {
+	if len(bases) != len(scalars) {
+		panic("group: MultiExp needs as many scalars as bases")
+	}
+	points := make([]*ristretto255.Element, len(bases))
+	ks := make([]*ristretto255.Scalar, len(scalars))
+	for i := range bases {
+		points[i] = ristrettoPoint(bases[i])
+		ks[i] = ristrettoScalar(scalars[i])
+	}
+	return ristrettoElement{e: ristretto255.NewElement().VarTimeMultiScalarMult(ks, points)}
+}
+
+// Encode returns the 32-byte canonical ristretto255 encoding of e.
+func (r *Ristretto255) Encode(e Element) []byte {
+	return ristrettoPoint(e).Encode(make([]byte, 0, ristrettoSize))
+}
+
+// Decode parses a canonical ristretto255 encoding, left-padding it with zeros to 32 bytes.
+func (r *Ristretto255) Decode(b []byte) (Element, error) {
+	if len(b) > ristrettoSize {
+		return nil, ErrInvalidElement
+	}
+	buf := make([]byte, ristrettoSize)
+	copy(buf[ristrettoSize-len(b):], b)
+	e := ristretto255.NewElement()
+	if err := e.Decode(buf); err != nil {
+		return nil, ErrInvalidElement
+	}
+	return ristrettoElement{e: e}, nil
+}
+
+func (r *Ristretto255) RandomScalar(rand io.Reader) (*big.Int, error) {
+	return randomScalar(rand, ristrettoOrder)
+}
+
+// HashToElement hashes the length-prefixed domain and msg with SHA-512 and maps the 64-byte digest to a point
+// with the ristretto255 one-way map.
+func (r *Ristretto255) HashToElement(domain, msg []byte) Element {
+	hash := sha512.New()
+	hash.Write(appendFramed(nil, domain))
+	hash.Write(msg)
+	return ristrettoElement{e: ristretto255.NewElement().FromUniformBytes(hash.Sum(nil))}
+}
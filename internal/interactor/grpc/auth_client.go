@@ -1,19 +1,27 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
+	"time"
 
 	"practical-case-test/config"
 	"practical-case-test/internal/app"
+	"practical-case-test/internal/domain/auth"
 	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/pkg/sessionjwt"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
 )
 
+// userAgent is the user agent the client announces to the verifier, ahead of the one of grpc-go.
+const userAgent = "zkp-prover"
+
 type AuthenticationClient struct {
 	cc   *grpc.ClientConn
 	cfg  *config.Config
@@ -21,12 +29,23 @@ type AuthenticationClient struct {
 	re   app.RegisterExecuter
 	co   app.CommitmentExecuter
 	cs   app.ComputeSExecuter
+	pn   app.ProveNonInteractiveExecuter
+	ds   app.DeriveSecretExecuter
 }
 
-func NewClient(address string, cfg *config.Config, re app.RegisterExecuter, co app.CommitmentExecuter, cs app.ComputeSExecuter) (*AuthenticationClient, error) {
+// NewClient connects to the verifier at address, over TLS if cfg configures it, see clientCredentials, and
+// returns a client that runs the protocol with the given executers.
+func NewClient(address string, cfg *config.Config, re app.RegisterExecuter, co app.CommitmentExecuter, cs app.ComputeSExecuter,
+	pn app.ProveNonInteractiveExecuter, ds app.DeriveSecretExecuter) (*AuthenticationClient, error) {
+	creds, err := clientCredentials(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up transport credentials, err: %w", err)
+	}
+
 	conn, err := grpc.NewClient(
 		address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUserAgent(userAgent),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial server %s, err: %w", address, err)
@@ -39,74 +58,263 @@ func NewClient(address string, cfg *config.Config, re app.RegisterExecuter, co a
 		re:   re,
 		co:   co,
 		cs:   cs,
+		pn:   pn,
+		ds:   ds,
 	}, nil
 }
 
 // Register sends a register request to the authentication server for the given user credentials.
-func (c *AuthenticationClient) Register(ctx context.Context, userName string,
-	userPassword *big.Int) error {
-	y1, y2, err := c.re.Exec(c.cfg, userPassword)
+// It draws a fresh salt, derives the secret x from the password and the salt with the Argon2id cost of the
+// configuration, and registers y1 and y2 computed from x together with the salt and the cost, so the user
+// can later log in from any machine knowing only the password.
+func (c *AuthenticationClient) Register(ctx context.Context, userName string, password string) error {
+	salt, err := app.NewSalt()
+	if err != nil {
+		return fmt.Errorf("could not generate salt, err: %w", err)
+	}
+	kdf := app.KDFParams(c.cfg)
+	x, err := c.ds.Exec(c.cfg, password, salt, kdf)
+	if err != nil {
+		return fmt.Errorf("could not derive secret, err: %w", err)
+	}
+	y1, y2, err := c.re.Exec(c.cfg, x)
 	if err != nil {
 		return fmt.Errorf("could not calculate y1 and y2, err: %w", err)
 	}
-	_, err = c.auth.Register(ctx, &interactor.RegisterRequest{User: userName, Y1: y1.Int64(), Y2: y2.Int64()})
+	_, err = c.auth.Register(ctx, &interactor.RegisterRequest{
+		User:            userName,
+		Y1Bytes:         y1.Bytes(),
+		Y2Bytes:         y2.Bytes(),
+		Salt:            salt,
+		Argon2Time:      kdf.Time,
+		Argon2MemoryKib: kdf.MemoryKiB,
+		Argon2Threads:   kdf.Threads,
+	})
 	if err != nil {
-		return fmt.Errorf("register request failed for user %s, err: %w", userName, err)
+		return fmt.Errorf("register request failed for user %s, err: %w", userName, fromStatusError(err))
 	}
 
-	slog.Info("registered user", "user", userName, "password", userPassword, "y1", y1, "y2", y2)
+	slog.Info("registered user", "user", userName, "y1", y1, "y2", y2)
 
 	return nil
 }
 
+// deriveSecret fetches the salt and the Argon2id cost parameters of the user from the server and derives the
+// secret x from the password with them, whatever the cost parameters of the local configuration.
+// The call options are passed to the GetSalt call.
+func (c AuthenticationClient) deriveSecret(ctx context.Context, userName string, password string,
+	opts ...grpc.CallOption) (*big.Int, error) {
+	saltResp, err := c.auth.GetSalt(ctx, &interactor.SaltRequest{User: userName}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get salt failed for user %s, err: %w", userName, fromStatusError(err))
+	}
+	kdf := auth.KDFParams{
+		Time:      saltResp.GetArgon2Time(),
+		MemoryKiB: saltResp.GetArgon2MemoryKib(),
+		Threads:   saltResp.GetArgon2Threads(),
+	}
+	x, err := c.ds.Exec(c.cfg, password, saltResp.GetSalt(), kdf)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive secret, err: %w", err)
+	}
+	return x, nil
+}
+
 // Login performs the login process for a user.
 //
 // The login process involves the following steps:
-//  1. Generate data for commitment.
-//  2. Send the commitment data to the server.
-//  3. Execute the challenge response.
-//  4. Verify authentication with the server.
+//  1. Fetch the salt of the user and derive the secret from the password.
+//  2. Generate data for commitment.
+//  3. Send the commitment data to the server.
+//  4. Execute the challenge response, bound to the TLS connection the challenge was received on.
+//  5. Verify authentication with the server.
 //
-// Upon successful authentication, the method returns the session ID.
-// Otherwise, it returns an error.
-func (c AuthenticationClient) Login(ctx context.Context, userName string, userPassword *big.Int) (string, error) {
+// Upon successful authentication, the method returns the response of the server, which carries the session ID
+// and, if the verifier issues them, a signed session token. Otherwise, it returns an error.
+func (c AuthenticationClient) Login(ctx context.Context, userName string, password string) (
+	*interactor.AuthenticationAnswerResponse, error) {
 	slog.Info("start login process")
 
+	x, err := c.deriveSecret(ctx, userName, password)
+	if err != nil {
+		return nil, err
+	}
+
 	slog.Info("generating data for commitment")
 	commitment, err := c.co.Exec(c.cfg)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	var p peer.Peer
 	challengeResp, err := c.auth.CreateAuthenticationChallenge(ctx, &interactor.AuthenticationChallengeRequest{
-		User: userName,
-		R1:   commitment.R1.Int64(),
-		R2:   commitment.R2.Int64(),
-	})
+		User:        userName,
+		R1Bytes:     commitment.R1.Bytes(),
+		R2Bytes:     commitment.R2.Bytes(),
+		DeviceLabel: c.cfg.DeviceLabel,
+	}, grpc.Peer(&p))
 	if err != nil {
-		return "", fmt.Errorf("create authentication challenge failed for user %s, err: %w", userName, err)
+		return nil, fmt.Errorf("create authentication challenge failed for user %s, err: %w", userName, fromStatusError(err))
+	}
+	binding, err := channelBinding(p.AuthInfo)
+	if err != nil {
+		return nil, err
 	}
 
 	slog.Info("commitment sent successfully", "challenge response", challengeResp)
 
 	slog.Info("processing challenge response")
-	s, err := c.cs.Exec(c.cfg, userPassword, commitment.K, challengeResp)
+	s, err := c.cs.Exec(c.cfg, x, commitment.K, challengeResp, binding)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	slog.Info("verifying authentication with the server.")
 	authResp, err := c.auth.VerifyAuthentication(ctx, &interactor.AuthenticationAnswerRequest{
 		AuthId: challengeResp.GetAuthId(),
-		S:      s.Int64(),
+		SBytes: s.Bytes(),
 	})
 	if err != nil {
-		return "", fmt.Errorf("verify authentication failed for user %s, err: %w", userName, err)
+		return nil, fmt.Errorf("verify authentication failed for user %s, err: %w", userName, fromStatusError(err))
 	}
 
-	slog.Info("user authenticated successfully", "session id", authResp.GetSessionId())
+	slog.Info("user authenticated successfully", "user", userName, "signed", authResp.GetSignedToken() != "")
+
+	return authResp, nil
+}
+
+// maxProofAttempts bounds how many proofs LoginNonInteractive sends when its proofs keep reaching the verifier
+// over another connection than the one they were bound to.
+const maxProofAttempts = 3
+
+// LoginNonInteractive performs the login process for a user in a single round trip.
+//
+// After deriving the secret from the password and the salt of the user, and instead of asking the server
+// for a challenge, the client derives it by hashing the proof transcript
+// together with the current time and the channel binding of its TLS connection to the verifier
+// (Fiat-Shamir), and sends the commitment and the response at once.
+// The server must see the timestamp within its configured skew of its own clock.
+//
+// The proof is bound to the connection the salt was fetched on, since the connection a call will use is only
+// known once it is made. If grpc-go reconnected in between, the proof reaches the verifier over another TLS
+// connection and is rejected as invalid; the client then proves again for the connection that carried the
+// rejected proof, at most maxProofAttempts times in all.
+//
+// Upon successful authentication, the method returns the response of the server, which carries the session ID
+// and, if the verifier issues them, a signed session token. Otherwise, it returns an error.
+func (c AuthenticationClient) LoginNonInteractive(ctx context.Context, userName string, password string) (
+	*interactor.NonInteractiveLoginResponse, error) {
+	slog.Info("start non-interactive login process")
 
-	return authResp.GetSessionId(), nil
+	var p peer.Peer
+	x, err := c.deriveSecret(ctx, userName, password, grpc.Peer(&p))
+	if err != nil {
+		return nil, err
+	}
+	binding, err := channelBinding(p.AuthInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		proof, err := c.pn.Exec(c.cfg, userName, x, time.Now().Unix(), binding)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.auth.LoginNonInteractive(ctx, &interactor.NonInteractiveLoginRequest{
+			User:        userName,
+			R1:          proof.R1.Bytes(),
+			R2:          proof.R2.Bytes(),
+			S:           proof.S.Bytes(),
+			Timestamp:   proof.Timestamp,
+			DeviceLabel: c.cfg.DeviceLabel,
+		}, grpc.Peer(&p))
+		if err == nil {
+			slog.Info("user authenticated successfully", "user", userName, "signed", resp.GetSignedToken() != "")
+			return resp, nil
+		}
+		err = fromStatusError(err)
+
+		sent, bindingErr := channelBinding(p.AuthInfo)
+		if !errors.Is(err, app.ErrInvalidProof) || bindingErr != nil || bytes.Equal(sent, binding) ||
+			attempt == maxProofAttempts {
+			return nil, fmt.Errorf("non-interactive login failed for user %s, err: %w", userName, err)
+		}
+		slog.Info("proof reached the verifier over another connection, proving again", "user", userName)
+		binding = sent
+	}
+}
+
+// ValidateSession asks the verifier whether sessionID is a live session of userName and returns its answer,
+// which also carries the login time and expiry of a known session.
+func (c AuthenticationClient) ValidateSession(ctx context.Context, userName string, sessionID string) (
+	*interactor.ValidateSessionResponse, error) {
+	res, err := c.auth.ValidateSession(ctx, &interactor.ValidateSessionRequest{User: userName, SessionId: sessionID})
+	if err != nil {
+		return nil, fmt.Errorf("session validation failed for user %s, err: %w", userName, fromStatusError(err))
+	}
+	return res, nil
+}
+
+// RefreshSession extends the session sessionID of userName, which must still be active, and returns the Unix
+// time at which it now expires.
+func (c AuthenticationClient) RefreshSession(ctx context.Context, userName string, sessionID string) (int64, error) {
+	res, err := c.auth.RefreshSession(ctx, &interactor.RefreshSessionRequest{User: userName, SessionId: sessionID})
+	if err != nil {
+		return 0, fmt.Errorf("session refresh failed for user %s, err: %w", userName, fromStatusError(err))
+	}
+	return res.GetExpiresAt(), nil
+}
+
+// Logout revokes the session sessionID of userName.
+func (c AuthenticationClient) Logout(ctx context.Context, userName string, sessionID string) error {
+	if _, err := c.auth.Logout(ctx, &interactor.LogoutRequest{User: userName, SessionId: sessionID}); err != nil {
+		return fmt.Errorf("logout failed for user %s, err: %w", userName, fromStatusError(err))
+	}
+	return nil
+}
+
+// ListSessions returns the active sessions of targetUser, using the session sessionID of userName to authorize
+// the request. An empty targetUser lists the sessions of userName itself.
+func (c AuthenticationClient) ListSessions(ctx context.Context, userName string, sessionID string, targetUser string) (
+	[]*interactor.SessionInfo, error) {
+	res, err := c.auth.ListSessions(ctx, &interactor.ListSessionsRequest{
+		User:       userName,
+		SessionId:  sessionID,
+		TargetUser: targetUser,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("session listing failed for user %s, err: %w", userName, fromStatusError(err))
+	}
+	return res.GetSessions(), nil
+}
+
+// RevokeAllSessions revokes every session of targetUser, or of userName if targetUser is empty, using the
+// session sessionID of userName to authorize the request, and returns how many sessions were revoked. With
+// keepCurrent set, sessionID itself survives when userName revokes its own sessions.
+func (c AuthenticationClient) RevokeAllSessions(ctx context.Context, userName string, sessionID string, targetUser string,
+	keepCurrent bool) (int, error) {
+	res, err := c.auth.RevokeAllSessions(ctx, &interactor.RevokeAllSessionsRequest{
+		User:        userName,
+		SessionId:   sessionID,
+		TargetUser:  targetUser,
+		KeepCurrent: keepCurrent,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("session revocation failed for user %s, err: %w", userName, fromStatusError(err))
+	}
+	return int(res.GetRevoked()), nil
+}
+
+// PublicKeys fetches the keys the signed session tokens of the verifier are verified with, to be passed to
+// sessionjwt.Verify. The key set is empty if the verifier does not issue signed tokens.
+func (c AuthenticationClient) PublicKeys(ctx context.Context) (*sessionjwt.KeySet, error) {
+	res, err := c.auth.GetPublicKeys(ctx, &interactor.GetPublicKeysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("get public keys failed, err: %w", fromStatusError(err))
+	}
+	return sessionjwt.ParseKeySet([]byte(res.GetJwks()))
 }
 
 // Close closes the client connection. If the connection is not nil,
@@ -2,20 +2,28 @@ package grpc
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
 	"practical-case-test/config"
 	"practical-case-test/internal/app"
 	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/repository/memory"
+	"practical-case-test/pkg/sessionjwt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestAuthenticationClient_Login(t *testing.T) {
 	type args struct {
 		ctx          context.Context
 		userName     string
-		userPassword *big.Int
+		userPassword string
 	}
 	tests := []struct {
 		name    string
@@ -30,7 +38,7 @@ func TestAuthenticationClient_Login(t *testing.T) {
 		{
 			name: "Test Case 1: Successful Login",
 			auth: &MockAuthClient{
-				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", C: 0},
+				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", CBytes: big.NewInt(1).Bytes()},
 				AuthenticationAnswerResponse:    &interactor.AuthenticationAnswerResponse{SessionId: "sessionId"},
 			},
 			co: &MockCommitmentExecuter{
@@ -43,7 +51,7 @@ func TestAuthenticationClient_Login(t *testing.T) {
 			args: args{
 				ctx:          context.Background(),
 				userName:     "test",
-				userPassword: big.NewInt(12345),
+				userPassword: "password",
 			},
 			wantErr: false,
 		},
@@ -62,14 +70,14 @@ func TestAuthenticationClient_Login(t *testing.T) {
 			args: args{
 				ctx:          context.Background(),
 				userName:     "test",
-				userPassword: big.NewInt(12345),
+				userPassword: "password",
 			},
 			wantErr: true,
 		},
 		{
 			name: "Test Case 3: Failed Login due to Verify Authentication error",
 			auth: &MockAuthClient{
-				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", C: 0},
+				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", CBytes: big.NewInt(1).Bytes()},
 				AuthenticationAnswerError:       errors.New("verify authentication error"),
 			},
 			co: &MockCommitmentExecuter{
@@ -82,14 +90,33 @@ func TestAuthenticationClient_Login(t *testing.T) {
 			args: args{
 				ctx:          context.Background(),
 				userName:     "test",
-				userPassword: big.NewInt(12345),
+				userPassword: "password",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 4: Failed Login due to GetSalt error",
+			auth: &MockAuthClient{
+				SaltError: errors.New("get salt error"),
+			},
+			co: &MockCommitmentExecuter{
+				Result: &app.CommitmentResult{R1: big.NewInt(1), R2: big.NewInt(1), K: big.NewInt(1)},
+			},
+			cs: &MockComputeSExecuter{
+				Result: big.NewInt(1),
+			},
+			cfg: &config.Config{},
+			args: args{
+				ctx:          context.Background(),
+				userName:     "test",
+				userPassword: "password",
 			},
 			wantErr: true,
 		},
 		{
-			name: "Test Case 4: Failed Login due to Commitment Exec error",
+			name: "Test Case 5: Failed Login due to Commitment Exec error",
 			auth: &MockAuthClient{
-				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", C: 0},
+				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", CBytes: big.NewInt(1).Bytes()},
 			},
 			co: &MockCommitmentExecuter{
 				Err: errors.New("commitment exec error"),
@@ -101,14 +128,14 @@ func TestAuthenticationClient_Login(t *testing.T) {
 			args: args{
 				ctx:          context.Background(),
 				userName:     "test",
-				userPassword: big.NewInt(12345),
+				userPassword: "password",
 			},
 			wantErr: true,
 		},
 		{
-			name: "Test Case 5: Failed Login due to ComputeS Exec error",
+			name: "Test Case 6: Failed Login due to ComputeS Exec error",
 			auth: &MockAuthClient{
-				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", C: 0},
+				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", CBytes: big.NewInt(1).Bytes()},
 			},
 			co: &MockCommitmentExecuter{
 				Result: &app.CommitmentResult{R1: big.NewInt(1), R2: big.NewInt(1), K: big.NewInt(1)},
@@ -120,7 +147,7 @@ func TestAuthenticationClient_Login(t *testing.T) {
 			args: args{
 				ctx:          context.Background(),
 				userName:     "test",
-				userPassword: big.NewInt(12345),
+				userPassword: "password",
 			},
 			wantErr: true,
 		},
@@ -131,7 +158,7 @@ func TestAuthenticationClient_Login(t *testing.T) {
 			address := ":50051"
 
 			// Create client with mocks
-			c, err := NewClient(address, tt.cfg, tt.re, tt.co, tt.cs)
+			c, err := NewClient(address, tt.cfg, tt.re, tt.co, tt.cs, nil, &MockDeriveSecretExecuter{Result: big.NewInt(1)})
 			if err != nil {
 				t.Fatalf("failed to create client: %v", err)
 			}
@@ -151,23 +178,24 @@ func TestAuthenticationClient_Register(t *testing.T) {
 	type args struct {
 		ctx          context.Context
 		userName     string
-		userPassword *big.Int
+		userPassword string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		cfg     *config.Config
-		auth    *MockAuthClient
-		re      *MockRegisterExecuter
-		co      *MockCommitmentExecuter
-		cs      *MockComputeSExecuter
-		wantErr bool
+		name      string
+		args      args
+		cfg       *config.Config
+		auth      *MockAuthClient
+		re        *MockRegisterExecuter
+		co        *MockCommitmentExecuter
+		cs        *MockComputeSExecuter
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "Test Case 1: Successful Register",
 			auth: &MockAuthClient{
 				RegisterResponse:                &interactor.RegisterResponse{},
-				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", C: 0},
+				AuthenticationChallengeResponse: &interactor.AuthenticationChallengeResponse{AuthId: "authId", CBytes: big.NewInt(1).Bytes()},
 				AuthenticationAnswerResponse:    &interactor.AuthenticationAnswerResponse{SessionId: "sessionId"},
 			},
 			re: &MockRegisterExecuter{
@@ -184,7 +212,7 @@ func TestAuthenticationClient_Register(t *testing.T) {
 			args: args{
 				ctx:          context.Background(),
 				userName:     "test",
-				userPassword: big.NewInt(12345),
+				userPassword: "password",
 			},
 			wantErr: false,
 		},
@@ -209,7 +237,7 @@ func TestAuthenticationClient_Register(t *testing.T) {
 			args: args{
 				ctx:          context.Background(),
 				userName:     "test",
-				userPassword: big.NewInt(12345),
+				userPassword: "password",
 			},
 			wantErr: true,
 		},
@@ -232,10 +260,28 @@ func TestAuthenticationClient_Register(t *testing.T) {
 			args: args{
 				ctx:          context.Background(),
 				userName:     "test",
-				userPassword: big.NewInt(12345),
+				userPassword: "password",
 			},
 			wantErr: true,
 		},
+		{
+			name: "Test Case 4: Failed Register of an existing user",
+			auth: &MockAuthClient{
+				RegisterError: overTheWire(toStatusError(fmt.Errorf("failed to register user: %w", memory.ErrUserAlreadyExists))),
+			},
+			re: &MockRegisterExecuter{
+				Y1: big.NewInt(1),
+				Y2: big.NewInt(1),
+			},
+			cfg: &config.Config{},
+			args: args{
+				ctx:          context.Background(),
+				userName:     "test",
+				userPassword: "password",
+			},
+			wantErr:   true,
+			wantErrIs: memory.ErrUserAlreadyExists,
+		},
 	}
 
 	for _, tt := range tests {
@@ -243,7 +289,7 @@ func TestAuthenticationClient_Register(t *testing.T) {
 			address := ":50051"
 
 			// Create client with mocks
-			c, err := NewClient(address, tt.cfg, tt.re, tt.co, tt.cs)
+			c, err := NewClient(address, tt.cfg, tt.re, tt.co, tt.cs, nil, &MockDeriveSecretExecuter{Result: big.NewInt(1)})
 			if err != nil {
 				t.Fatalf("failed to create client: %v", err)
 			}
@@ -254,6 +300,318 @@ func TestAuthenticationClient_Register(t *testing.T) {
 			if err = c.Register(tt.args.ctx, tt.args.userName, tt.args.userPassword); (err != nil) != tt.wantErr {
 				t.Errorf("Register() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.wantErrIs != nil && (!errors.Is(err, tt.wantErrIs) || status.Code(err) != codes.AlreadyExists) {
+				t.Errorf("Register() error = %v, want %v with code %v", err, tt.wantErrIs, codes.AlreadyExists)
+			}
+		})
+	}
+}
+
+func TestAuthenticationClient_LoginNonInteractive(t *testing.T) {
+	proof := &app.NonInteractiveProof{R1: big.NewInt(1), R2: big.NewInt(1), S: big.NewInt(1), Timestamp: 1700000000}
+
+	tests := []struct {
+		name    string
+		auth    *MockAuthClient
+		pn      *MockProveNonInteractiveExecuter
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1: Successful Login",
+			auth: &MockAuthClient{
+				NonInteractiveLoginResponse: &interactor.NonInteractiveLoginResponse{SessionId: "sessionId", SignedToken: "signedToken"},
+			},
+			pn:      &MockProveNonInteractiveExecuter{Result: proof},
+			wantErr: false,
+		},
+		{
+			name:    "Test Case 2: Failed Login due to ProveNonInteractive Exec error",
+			auth:    &MockAuthClient{},
+			pn:      &MockProveNonInteractiveExecuter{Err: errors.New("prove exec error")},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 3: Failed Login due to LoginNonInteractive error",
+			auth: &MockAuthClient{
+				NonInteractiveLoginError: errors.New("login error"),
+			},
+			pn:      &MockProveNonInteractiveExecuter{Result: proof},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(":50051", &config.Config{}, nil, nil, nil, tt.pn, &MockDeriveSecretExecuter{Result: big.NewInt(1)})
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Replace auth client with a mock
+			c.auth = tt.auth
+
+			resp, err := c.LoginNonInteractive(context.Background(), "test", "password")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoginNonInteractive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (resp.GetSessionId() != "sessionId" || resp.GetSignedToken() != "signedToken") {
+				t.Errorf("LoginNonInteractive() = %v, want session ID %q and signed token %q", resp, "sessionId", "signedToken")
+			}
+		})
+	}
+}
+
+func TestAuthenticationClient_ValidateSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    *MockAuthClient
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1: Valid session",
+			auth: &MockAuthClient{
+				ValidateSessionResponse: &interactor.ValidateSessionResponse{Valid: true, User: "test", LoginTimestamp: 1, ExpiresAt: 2},
+			},
+		},
+		{
+			name:    "Test Case 2: Failed validation",
+			auth:    &MockAuthClient{ValidateSessionError: errors.New("validate error")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(":50051", &config.Config{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Replace auth client with a mock
+			c.auth = tt.auth
+
+			got, err := c.ValidateSession(context.Background(), "test", "sessionId")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.auth.ValidateSessionResponse {
+				t.Errorf("ValidateSession() = %v, want %v", got, tt.auth.ValidateSessionResponse)
+			}
+		})
+	}
+}
+
+func TestAuthenticationClient_RefreshSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    *MockAuthClient
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1: Successful refresh",
+			auth: &MockAuthClient{RefreshSessionResponse: &interactor.RefreshSessionResponse{ExpiresAt: 1234}},
+			want: 1234,
+		},
+		{
+			name:    "Test Case 2: Failed refresh",
+			auth:    &MockAuthClient{RefreshSessionError: errors.New("refresh error")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(":50051", &config.Config{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Replace auth client with a mock
+			c.auth = tt.auth
+
+			got, err := c.RefreshSession(context.Background(), "test", "sessionId")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RefreshSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RefreshSession() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticationClient_Logout(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    *MockAuthClient
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1: Successful logout",
+			auth: &MockAuthClient{},
+		},
+		{
+			name:    "Test Case 2: Failed logout",
+			auth:    &MockAuthClient{LogoutError: errors.New("logout error")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(":50051", &config.Config{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Replace auth client with a mock
+			c.auth = tt.auth
+
+			if err = c.Logout(context.Background(), "test", "sessionId"); (err != nil) != tt.wantErr {
+				t.Errorf("Logout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticationClient_ListSessions(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    *MockAuthClient
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1: Successful listing",
+			auth: &MockAuthClient{ListSessionsResponse: &interactor.ListSessionsResponse{Sessions: []*interactor.SessionInfo{
+				{SessionId: "first", LoginTimestamp: 1234},
+				{SessionId: "second", LoginTimestamp: 2345},
+			}}},
+			wantLen: 2,
+		},
+		{
+			name:    "Test Case 2: Failed listing",
+			auth:    &MockAuthClient{ListSessionsError: errors.New("listing error")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(":50051", &config.Config{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Replace auth client with a mock
+			c.auth = tt.auth
+
+			got, err := c.ListSessions(context.Background(), "test", "sessionId", "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("ListSessions() returned %d sessions, want %d", len(got), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestAuthenticationClient_RevokeAllSessions(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    *MockAuthClient
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1: Successful revocation",
+			auth: &MockAuthClient{RevokeAllSessionsResponse: &interactor.RevokeAllSessionsResponse{Revoked: 2}},
+			want: 2,
+		},
+		{
+			name:    "Test Case 2: Failed revocation",
+			auth:    &MockAuthClient{RevokeAllSessionsError: errors.New("revocation error")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(":50051", &config.Config{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Replace auth client with a mock
+			c.auth = tt.auth
+
+			got, err := c.RevokeAllSessions(context.Background(), "test", "sessionId", "", true)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RevokeAllSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RevokeAllSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticationClient_PublicKeys(t *testing.T) {
+	public, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keys, err := sessionjwt.NewKeySet(public)
+	if err != nil {
+		t.Fatalf("failed to build key set: %v", err)
+	}
+	jwks, err := json.Marshal(keys)
+	if err != nil {
+		t.Fatalf("failed to encode key set: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		auth    *MockAuthClient
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1: Successful lookup",
+			auth:    &MockAuthClient{GetPublicKeysResponse: &interactor.GetPublicKeysResponse{Jwks: string(jwks)}},
+			wantLen: 1,
+		},
+		{
+			name:    "Test Case 2: Failed lookup",
+			auth:    &MockAuthClient{GetPublicKeysError: errors.New("lookup error")},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3: Invalid key set",
+			auth:    &MockAuthClient{GetPublicKeysResponse: &interactor.GetPublicKeysResponse{Jwks: "not json"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(":50051", &config.Config{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			// Replace auth client with a mock
+			c.auth = tt.auth
+
+			got, err := c.PublicKeys(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PublicKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(got.Keys) != tt.wantLen {
+				t.Errorf("PublicKeys() returned %d keys, want %d", len(got.Keys), tt.wantLen)
+			}
 		})
 	}
 }
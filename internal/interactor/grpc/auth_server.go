@@ -2,54 +2,104 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 
 	"practical-case-test/config"
 	"practical-case-test/internal/app"
+	"practical-case-test/internal/domain/auth"
 	interactor "practical-case-test/internal/interactor/proto"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
+// AuthenticationServer implements the Auth service on top of the application executers. Its handlers report
+// failures as a *StatusError whose status code and details name the domain error, see toStatusError.
 type AuthenticationServer struct {
 	interactor.UnimplementedAuthServer
 	cfg *config.Config
 	ru  app.RegisterUserExecuter
 	cac app.CreateAuthenticationChallengeExecuter
 	va  app.VerifyAuthenticationExecuter
+	ln  app.LoginNonInteractiveExecuter
+	gs  app.GetSaltExecuter
+	vs  app.ValidateSessionExecuter
+	rs  app.RefreshSessionExecuter
+	lo  app.LogoutExecuter
+	ls  app.ListSessionsExecuter
+	ra  app.RevokeAllSessionsExecuter
+	pk  app.GetPublicKeysExecuter
 }
 
 func NewAuthenticationServer(cfg *config.Config, ru app.RegisterUserExecuter, cac app.CreateAuthenticationChallengeExecuter,
-	va app.VerifyAuthenticationExecuter) *AuthenticationServer {
-	return &AuthenticationServer{cfg: cfg, ru: ru, cac: cac, va: va}
+	va app.VerifyAuthenticationExecuter, ln app.LoginNonInteractiveExecuter, gs app.GetSaltExecuter,
+	vs app.ValidateSessionExecuter, rs app.RefreshSessionExecuter, lo app.LogoutExecuter, ls app.ListSessionsExecuter,
+	ra app.RevokeAllSessionsExecuter, pk app.GetPublicKeysExecuter) *AuthenticationServer {
+	return &AuthenticationServer{cfg: cfg, ru: ru, cac: cac, va: va, ln: ln, gs: gs, vs: vs, rs: rs, lo: lo, ls: ls, ra: ra,
+		pk: pk}
 }
 
+// Register stores the registration in the request. It executes the RegisterUserExecuter with the client
+// described by clientInfo, whose client certificate decides whether an admin may register, and returns its
+// error wrapped if the registration is refused or cannot be stored.
 func (a *AuthenticationServer) Register(ctx context.Context, in *interactor.RegisterRequest) (*interactor.RegisterResponse, error) {
 	user := in.GetUser()
 	slog.Info("received registration request", "user", user)
 
-	err := a.ru.Exec(ctx, in)
+	client, err := clientInfo(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to register user %q: %w", user, err)
+		return nil, toStatusError(err)
+	}
+	if err = a.ru.Exec(ctx, a.cfg, in, client); err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to register user %q: %w", user, err))
 	}
 	return &interactor.RegisterResponse{}, nil
 }
 
+// GetSalt returns the salt and the Argon2id cost parameters the user registered with, which the prover needs to
+// derive its secret from the password. For a user that is not registered it answers with a made-up salt and the
+// configured cost, see app.GetSalt.
+// It calls the Exec method of the `gs` (GetSaltExecuter) field and returns its error wrapped if the lookup fails.
+func (a *AuthenticationServer) GetSalt(ctx context.Context, in *interactor.SaltRequest) (*interactor.SaltResponse, error) {
+	user := in.GetUser()
+	slog.Info("received salt request", "user", user)
+
+	salt, kdf, err := a.gs.Exec(ctx, a.cfg, in)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to get salt of user %q: %w", user, err))
+	}
+	return &interactor.SaltResponse{
+		Salt:            salt,
+		Argon2Time:      kdf.Time,
+		Argon2MemoryKib: kdf.MemoryKiB,
+		Argon2Threads:   kdf.Threads,
+	}, nil
+}
+
 // CreateAuthenticationChallenge creates an authentication challenge for the user specified in the request.
 // It logs the user ID of the user making the request and calls the Execute method of the `cac` (CreateAuthenticationChallengeExecuter) field of the AuthenticationServer struct.
+// The challenge is attributed to the client described by clientInfo, which binds the response to the TLS
+// connection of the call.
 // If there is an error executing the challenge, it returns the error.
 // Otherwise, it creates an AuthenticationChallengeResponse with the AuthId and C values from the challenge, and returns it along with nil error.
 func (a *AuthenticationServer) CreateAuthenticationChallenge(ctx context.Context, in *interactor.AuthenticationChallengeRequest) (*interactor.AuthenticationChallengeResponse, error) {
 	userID := in.GetUser()
 	slog.Info("received challenge request", "user", userID)
 
-	challenge, err := a.cac.Exec(ctx, in)
+	client, err := clientInfo(ctx, in.GetDeviceLabel())
 	if err != nil {
-		return nil, fmt.Errorf("user %s failed challenge: %w", userID, err)
+		return nil, toStatusError(err)
+	}
+	challenge, err := a.cac.Exec(ctx, a.cfg, in, client)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("user %s failed challenge: %w", userID, err))
 	}
 
 	return &interactor.AuthenticationChallengeResponse{
 		AuthId: challenge.AuthID().String(),
-		C:      challenge.C().Int64(),
+		CBytes: challenge.C().Bytes(),
 	}, nil
 }
 
@@ -57,18 +107,193 @@ func (a *AuthenticationServer) CreateAuthenticationChallenge(ctx context.Context
 // It retrieves the authID from the request and logs the received request.
 // It then executes the VerifyAuthenticationExecuter to verify the session.
 // If an error occurs, it logs the failure, constructs an error message, and returns it.
-// Otherwise, it constructs an AuthenticationAnswerResponse with the session ID and returns it.
+// Otherwise, it constructs an AuthenticationAnswerResponse with the token of the new session, which serves as
+// its session ID, and the signed session token if the verifier issues them, and returns it.
 func (a *AuthenticationServer) VerifyAuthentication(ctx context.Context, in *interactor.AuthenticationAnswerRequest) (*interactor.AuthenticationAnswerResponse, error) {
 	authID := in.GetAuthId()
 	slog.Info("received verify authentication", "authID", authID)
 
-	session, err := a.va.Exec(ctx, a.cfg, in)
+	issued, err := a.va.Exec(ctx, a.cfg, in)
 	if err != nil {
 		slog.Error("failed to verify session", "authID", authID, "error", err)
-		return nil, fmt.Errorf("failed to authenticate %s: %w", authID, err)
+		return nil, toStatusError(fmt.Errorf("failed to authenticate %s: %w", authID, err))
 	}
 
 	return &interactor.AuthenticationAnswerResponse{
-		SessionId: session.ID().String(),
+		SessionId:   issued.Token,
+		SignedToken: issued.SignedToken,
 	}, nil
 }
+
+// LoginNonInteractive authenticates the user from the Fiat-Shamir proof in the request in a single round trip.
+// It executes the LoginNonInteractiveExecuter, which recomputes the challenge and verifies the proof without
+// any stored challenge state, and attributes the session to the client described by clientInfo. If an error
+// occurs, it logs the failure and returns it wrapped.
+// Otherwise, it returns a NonInteractiveLoginResponse with the token of the new session as its ID and the
+// signed session token if the verifier issues them.
+func (a *AuthenticationServer) LoginNonInteractive(ctx context.Context, in *interactor.NonInteractiveLoginRequest) (*interactor.NonInteractiveLoginResponse, error) {
+	userID := in.GetUser()
+	slog.Info("received non-interactive login", "user", userID, "timestamp", in.GetTimestamp())
+
+	client, err := clientInfo(ctx, in.GetDeviceLabel())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	issued, err := a.ln.Exec(ctx, a.cfg, in, client)
+	if err != nil {
+		slog.Error("failed to verify non-interactive proof", "user", userID, "error", err)
+		return nil, toStatusError(fmt.Errorf("failed to authenticate %s: %w", userID, err))
+	}
+
+	return &interactor.NonInteractiveLoginResponse{
+		SessionId:   issued.Token,
+		SignedToken: issued.SignedToken,
+	}, nil
+}
+
+// ValidateSession tells whether the session in the request is a live session of the user, so that other
+// services can check the session IDs handed to them. It executes the ValidateSessionExecuter and returns its
+// error wrapped if the lookup fails. An unknown or expired session is not an error but a response with
+// Valid set to false; for an expired one the response still carries the user, login time and expiry.
+func (a *AuthenticationServer) ValidateSession(ctx context.Context, in *interactor.ValidateSessionRequest) (*interactor.ValidateSessionResponse, error) {
+	userID := in.GetUser()
+	slog.Info("received session validation", "user", userID)
+
+	status, err := a.vs.Exec(ctx, a.cfg, in)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to validate session of %s: %w", userID, err))
+	}
+
+	res := &interactor.ValidateSessionResponse{Valid: status.Valid}
+	if status.Session != nil {
+		res.User = status.Session.UserID()
+		res.LoginTimestamp = status.Session.LoginTimestamp()
+		res.ExpiresAt = status.ExpiresAt
+	}
+	return res, nil
+}
+
+// RefreshSession extends the session in the request, which must still be active, and returns the time at
+// which it now expires. It executes the RefreshSessionExecuter and returns its error wrapped if the session
+// is unknown, has expired or cannot be refreshed.
+func (a *AuthenticationServer) RefreshSession(ctx context.Context, in *interactor.RefreshSessionRequest) (*interactor.RefreshSessionResponse, error) {
+	userID := in.GetUser()
+	slog.Info("received session refresh", "user", userID)
+
+	status, err := a.rs.Exec(ctx, a.cfg, in)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to refresh session of %s: %w", userID, err))
+	}
+
+	return &interactor.RefreshSessionResponse{ExpiresAt: status.ExpiresAt}, nil
+}
+
+// Logout revokes the session in the request. It executes the LogoutExecuter and returns its error wrapped
+// if the session is unknown or cannot be revoked.
+func (a *AuthenticationServer) Logout(ctx context.Context, in *interactor.LogoutRequest) (*interactor.LogoutResponse, error) {
+	userID := in.GetUser()
+	slog.Info("received logout", "user", userID)
+
+	if err := a.lo.Exec(ctx, a.cfg, in); err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to log out %s: %w", userID, err))
+	}
+
+	return &interactor.LogoutResponse{}, nil
+}
+
+// ListSessions returns the active sessions of the target user in the request, or of the caller if no target is
+// given, together with the client each was opened from, so that a user can see where they are logged in.
+// Sessions are listed by their ID in the repository, the hash of their token, which cannot be used to
+// authenticate; the session the request was made with is marked as current. The request must carry a live session of the caller,
+// and only admins may list the sessions of another user. It executes the ListSessionsExecuter with the client
+// described by clientInfo, whose client certificate decides whether the caller may act as an admin, and returns
+// its error wrapped if the caller is not allowed to list the sessions or the lookup fails.
+func (a *AuthenticationServer) ListSessions(ctx context.Context, in *interactor.ListSessionsRequest) (*interactor.ListSessionsResponse, error) {
+	userID := in.GetUser()
+	slog.Info("received session listing", "user", userID, "target", in.GetTargetUser())
+
+	client, err := clientInfo(ctx, "")
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	sessions, err := a.ls.Exec(ctx, a.cfg, in, client)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to list sessions for %s: %w", userID, err))
+	}
+
+	res := &interactor.ListSessionsResponse{Sessions: make([]*interactor.SessionInfo, 0, len(sessions))}
+	for _, status := range sessions {
+		res.Sessions = append(res.Sessions, &interactor.SessionInfo{
+			SessionId:         status.Session.ID().String(),
+			Current:           status.Current,
+			LoginTimestamp:    status.Session.LoginTimestamp(),
+			LastSeenTimestamp: status.Session.LastSeenTimestamp(),
+			ExpiresAt:         status.ExpiresAt,
+			PeerAddress:       status.Session.Client().PeerAddress(),
+			UserAgent:         status.Session.Client().UserAgent(),
+			DeviceLabel:       status.Session.Client().DeviceLabel(),
+		})
+	}
+	return res, nil
+}
+
+// RevokeAllSessions revokes every session of the target user in the request, or of the caller if no target is
+// given, optionally keeping the session the request is made with. It has the same authorization rules as
+// ListSessions, executes the RevokeAllSessionsExecuter and returns how many sessions were revoked, or its
+// error wrapped.
+func (a *AuthenticationServer) RevokeAllSessions(ctx context.Context, in *interactor.RevokeAllSessionsRequest) (*interactor.RevokeAllSessionsResponse, error) {
+	userID := in.GetUser()
+	slog.Info("received session revocation", "user", userID, "target", in.GetTargetUser(), "keepCurrent", in.GetKeepCurrent())
+
+	client, err := clientInfo(ctx, "")
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	revoked, err := a.ra.Exec(ctx, a.cfg, in, client)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to revoke sessions for %s: %w", userID, err))
+	}
+
+	return &interactor.RevokeAllSessionsResponse{Revoked: int32(revoked)}, nil
+}
+
+// GetPublicKeys executes the GetPublicKeysExecuter and returns the keys signed session tokens are verified with
+// as a JSON Web Key Set, or its error wrapped.
+func (a *AuthenticationServer) GetPublicKeys(ctx context.Context, in *interactor.GetPublicKeysRequest) (*interactor.GetPublicKeysResponse, error) {
+	keys, err := a.pk.Exec(ctx, a.cfg, in)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to get public keys: %w", err))
+	}
+
+	jwks, err := json.Marshal(keys)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("failed to encode public keys: %w", err))
+	}
+
+	return &interactor.GetPublicKeysResponse{Jwks: string(jwks)}, nil
+}
+
+// clientInfo describes the client of the call in ctx: the address of the gRPC peer, the user agent it sent in
+// its metadata, the device label it chose, the channel binding of its TLS connection and the name of its
+// verified client certificate, any of which may be empty. It returns an error if the channel binding cannot
+// be exported from the TLS session.
+func clientInfo(ctx context.Context, deviceLabel string) (auth.ClientInfo, error) {
+	var peerAddress, userAgent, certName string
+	var binding []byte
+	if p, ok := peer.FromContext(ctx); ok {
+		if p.Addr != nil {
+			peerAddress = p.Addr.String()
+		}
+		certName = certificateName(p.AuthInfo)
+		var err error
+		if binding, err = channelBinding(p.AuthInfo); err != nil {
+			return auth.ClientInfo{}, err
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if agents := md.Get("user-agent"); len(agents) > 0 {
+			userAgent = agents[0]
+		}
+	}
+	return auth.NewClientInfo(peerAddress, userAgent, deviceLabel).WithChannelBinding(binding).WithCertificateName(certName), nil
+}
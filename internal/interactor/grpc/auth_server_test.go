@@ -2,8 +2,10 @@ package grpc
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"math/big"
+	"net"
 	"testing"
 	"time"
 
@@ -11,17 +13,22 @@ import (
 	"practical-case-test/internal/app"
 	"practical-case-test/internal/domain/auth"
 	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/internal/testcert"
+	"practical-case-test/pkg/sessionjwt"
 
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 func TestAuthenticationServer_CreateAuthenticationChallenge(t *testing.T) {
 	// Create an instance of a request.
 	req := &interactor.AuthenticationChallengeRequest{User: "test user"}
 
-	mockChallenge, err := auth.NewChallenge(big.NewInt(1234), req.GetUser(), 1, 3, time.Now().Unix())
+	mockChallenge, err := auth.NewChallenge(big.NewInt(1234), req.GetUser(), big.NewInt(1), big.NewInt(3), time.Now().Unix())
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -91,7 +98,7 @@ func TestAuthenticationServer_Register(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			mockRegisterUser := new(MockRegisterUser)
-			mockRegisterUser.On("Exec", context.Background(), tt.request).Return(tt.execErr)
+			mockRegisterUser.On("Exec", context.Background(), tt.request, auth.ClientInfo{}).Return(tt.execErr)
 
 			as := &AuthenticationServer{
 				cfg: &config.Config{},
@@ -110,6 +117,54 @@ func TestAuthenticationServer_Register(t *testing.T) {
 	}
 }
 
+func TestAuthenticationServer_GetSalt(t *testing.T) {
+	request := &interactor.SaltRequest{User: "userId"}
+	salt := []byte("0123456789abcdef")
+	kdf := auth.KDFParams{Time: 3, MemoryKiB: 65536, Threads: 4}
+
+	testCases := []struct {
+		name     string
+		execSalt []byte
+		execKDF  auth.KDFParams
+		execErr  error
+		wantErr  bool
+	}{
+		{
+			name:     "Successful lookup",
+			execSalt: salt,
+			execKDF:  kdf,
+			wantErr:  false,
+		},
+		{
+			name:    "Failed lookup",
+			execErr: errors.New("userID not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockGetSalt := new(MockGetSalt)
+			mockGetSalt.On("Exec", context.Background(), request).Return(tt.execSalt, tt.execKDF, tt.execErr)
+
+			as := NewAuthenticationServer(&config.Config{}, nil, nil, nil, nil, mockGetSalt, nil, nil, nil, nil, nil, nil)
+			resp, err := as.GetSalt(context.Background(), request)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, salt, resp.GetSalt())
+				require.Equal(t, kdf.Time, resp.GetArgon2Time())
+				require.Equal(t, kdf.MemoryKiB, resp.GetArgon2MemoryKib())
+				require.Equal(t, kdf.Threads, resp.GetArgon2Threads())
+			}
+
+			mockGetSalt.AssertExpectations(t)
+		})
+	}
+}
+
 func TestAuthenticationServer_VerifyAuthentication(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -134,7 +189,7 @@ func TestAuthenticationServer_VerifyAuthentication(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			server := NewAuthenticationServer(nil, nil, nil, tt.verifyAuth)
+			server := NewAuthenticationServer(nil, nil, nil, tt.verifyAuth, nil, nil, nil, nil, nil, nil, nil, nil)
 			resp, err := server.VerifyAuthentication(context.TODO(), tt.request)
 
 			if tt.expectError {
@@ -143,7 +198,389 @@ func TestAuthenticationServer_VerifyAuthentication(t *testing.T) {
 			}
 
 			require.NoError(t, err, "Got unexpected error")
-			require.NoError(t, uuid.Validate(resp.GetSessionId()), "Expected uuid formed ID, got %v", resp.GetSessionId())
+			require.Equal(t, "token", resp.GetSessionId(), "Expected the token of the issued session")
+			require.Equal(t, "signed-token", resp.GetSignedToken())
+		})
+	}
+}
+
+func TestAuthenticationServer_LoginNonInteractive(t *testing.T) {
+	tests := []struct {
+		name        string
+		login       app.LoginNonInteractiveExecuter
+		request     *interactor.NonInteractiveLoginRequest
+		expectError bool
+	}{
+		{
+			name:        "Successful authentication",
+			login:       &MockLoginNonInteractiveSuccess{},
+			request:     &interactor.NonInteractiveLoginRequest{User: "userId"},
+			expectError: false,
+		},
+		{
+			name:        "Failed authentication",
+			login:       &MockLoginNonInteractiveFail{},
+			request:     &interactor.NonInteractiveLoginRequest{User: "userId"},
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := NewAuthenticationServer(nil, nil, nil, nil, tt.login, nil, nil, nil, nil, nil, nil, nil)
+			resp, err := server.LoginNonInteractive(context.TODO(), tt.request)
+
+			if tt.expectError {
+				require.ErrorIs(t, err, app.ErrInvalidProof)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err, "Got unexpected error")
+			require.Equal(t, "token", resp.GetSessionId(), "Expected the token of the issued session")
+			require.Equal(t, "signed-token", resp.GetSignedToken())
+		})
+	}
+}
+
+func TestAuthenticationServer_ValidateSession(t *testing.T) {
+	request := &interactor.ValidateSessionRequest{User: "userId", SessionId: "token"}
+	session, err := auth.NewSession(auth.SessionHash{1}, "userId", 1234)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		status   *app.SessionStatus
+		execErr  error
+		wantResp *interactor.ValidateSessionResponse
+		wantErr  bool
+	}{
+		{
+			name:     "Live session",
+			status:   &app.SessionStatus{Valid: true, Session: session, ExpiresAt: 5678},
+			wantResp: &interactor.ValidateSessionResponse{Valid: true, User: "userId", LoginTimestamp: 1234, ExpiresAt: 5678},
+		},
+		{
+			name:     "Expired session",
+			status:   &app.SessionStatus{Valid: false, Session: session, ExpiresAt: 5678},
+			wantResp: &interactor.ValidateSessionResponse{Valid: false, User: "userId", LoginTimestamp: 1234, ExpiresAt: 5678},
+		},
+		{
+			name:     "Unknown session",
+			status:   &app.SessionStatus{},
+			wantResp: &interactor.ValidateSessionResponse{},
+		},
+		{
+			name:    "Failed lookup",
+			execErr: app.ErrInvalidSessionID,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockValidate := new(MockValidateSession)
+			mockValidate.On("Exec", context.Background(), request).Return(tt.status, tt.execErr)
+
+			as := NewAuthenticationServer(&config.Config{}, nil, nil, nil, nil, nil, mockValidate, nil, nil, nil, nil, nil)
+			resp, err := as.ValidateSession(context.Background(), request)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantResp.GetValid(), resp.GetValid())
+				require.Equal(t, tt.wantResp.GetUser(), resp.GetUser())
+				require.Equal(t, tt.wantResp.GetLoginTimestamp(), resp.GetLoginTimestamp())
+				require.Equal(t, tt.wantResp.GetExpiresAt(), resp.GetExpiresAt())
+			}
+
+			mockValidate.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticationServer_RefreshSession(t *testing.T) {
+	request := &interactor.RefreshSessionRequest{User: "userId", SessionId: "token"}
+	session, err := auth.NewSession(auth.SessionHash{1}, "userId", 1234)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		status  *app.SessionStatus
+		execErr error
+		wantErr bool
+	}{
+		{
+			name:   "Successful refresh",
+			status: &app.SessionStatus{Valid: true, Session: session, ExpiresAt: 5678},
+		},
+		{
+			name:    "Expired session",
+			execErr: app.ErrSessionExpired,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockRefresh := new(MockRefreshSession)
+			mockRefresh.On("Exec", context.Background(), request).Return(tt.status, tt.execErr)
+
+			as := NewAuthenticationServer(&config.Config{}, nil, nil, nil, nil, nil, nil, mockRefresh, nil, nil, nil, nil)
+			resp, err := as.RefreshSession(context.Background(), request)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.status.ExpiresAt, resp.GetExpiresAt())
+			}
+
+			mockRefresh.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticationServer_Logout(t *testing.T) {
+	request := &interactor.LogoutRequest{User: "userId", SessionId: "token"}
+
+	testCases := []struct {
+		name    string
+		execErr error
+		wantErr bool
+	}{
+		{
+			name: "Successful logout",
+		},
+		{
+			name:    "Failed logout",
+			execErr: errors.New("session not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockLogout := new(MockLogout)
+			mockLogout.On("Exec", context.Background(), request).Return(tt.execErr)
+
+			as := NewAuthenticationServer(&config.Config{}, nil, nil, nil, nil, nil, nil, nil, mockLogout, nil, nil, nil)
+			_, err := as.Logout(context.Background(), request)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			mockLogout.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticationServer_ListSessions(t *testing.T) {
+	request := &interactor.ListSessionsRequest{User: "userId", SessionId: "token"}
+	opened, err := auth.NewSession(auth.SessionHash{1}, "userId", 1234)
+	require.NoError(t, err)
+	first := opened.WithClient(auth.NewClientInfo("192.0.2.1:54321", "zkp-prover grpc-go/1.65.0", "phone"))
+	second, err := auth.NewSession(auth.SessionHash{2}, "userId", 2345)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		sessions []app.SessionStatus
+		execErr  error
+		wantErr  bool
+	}{
+		{
+			name: "Two sessions",
+			sessions: []app.SessionStatus{
+				{Valid: true, Session: &first, ExpiresAt: 5678, Current: true},
+				{Valid: true, Session: second, ExpiresAt: 6789},
+			},
+		},
+		{
+			name:     "No sessions",
+			sessions: []app.SessionStatus{},
+		},
+		{
+			name:    "Not authenticated",
+			execErr: app.ErrUnauthenticated,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockList := new(MockListSessions)
+			mockList.On("Exec", context.Background(), request, auth.ClientInfo{}).Return(tt.sessions, tt.execErr)
+
+			as := NewAuthenticationServer(&config.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, mockList, nil, nil)
+			resp, err := as.ListSessions(context.Background(), request)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, resp.GetSessions(), len(tt.sessions))
+				for i, info := range resp.GetSessions() {
+					require.Equal(t, tt.sessions[i].Session.ID().String(), info.GetSessionId())
+					require.Equal(t, tt.sessions[i].Current, info.GetCurrent())
+					require.Equal(t, tt.sessions[i].Session.LoginTimestamp(), info.GetLoginTimestamp())
+					require.Equal(t, tt.sessions[i].Session.LastSeenTimestamp(), info.GetLastSeenTimestamp())
+					require.Equal(t, tt.sessions[i].ExpiresAt, info.GetExpiresAt())
+					require.Equal(t, tt.sessions[i].Session.Client().PeerAddress(), info.GetPeerAddress())
+					require.Equal(t, tt.sessions[i].Session.Client().UserAgent(), info.GetUserAgent())
+					require.Equal(t, tt.sessions[i].Session.Client().DeviceLabel(), info.GetDeviceLabel())
+				}
+			}
+
+			mockList.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticationServer_RevokeAllSessions(t *testing.T) {
+	request := &interactor.RevokeAllSessionsRequest{User: "userId", SessionId: "token", KeepCurrent: true}
+
+	testCases := []struct {
+		name    string
+		revoked int
+		execErr error
+		wantErr bool
+	}{
+		{
+			name:    "Successful revocation",
+			revoked: 3,
+		},
+		{
+			name:    "Permission denied",
+			execErr: app.ErrPermissionDenied,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockRevoke := new(MockRevokeAllSessions)
+			mockRevoke.On("Exec", context.Background(), request, auth.ClientInfo{}).Return(tt.revoked, tt.execErr)
+
+			as := NewAuthenticationServer(&config.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockRevoke, nil)
+			resp, err := as.RevokeAllSessions(context.Background(), request)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, int32(tt.revoked), resp.GetRevoked())
+			}
+
+			mockRevoke.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticationServer_GetPublicKeys(t *testing.T) {
+	request := &interactor.GetPublicKeysRequest{}
+	public, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	keys, err := sessionjwt.NewKeySet(public)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		keys    *sessionjwt.KeySet
+		execErr error
+		wantErr bool
+	}{
+		{
+			name: "Signing key",
+			keys: keys,
+		},
+		{
+			name:    "Invalid signing key",
+			execErr: sessionjwt.ErrInvalidKey,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockKeys := new(MockGetPublicKeys)
+			mockKeys.On("Exec", context.Background(), request).Return(tt.keys, tt.execErr)
+
+			as := NewAuthenticationServer(&config.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockKeys)
+			resp, err := as.GetPublicKeys(context.Background(), request)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+				parsed, parseErr := sessionjwt.ParseKeySet([]byte(resp.GetJwks()))
+				require.NoError(t, parseErr)
+				require.Equal(t, tt.keys, parsed)
+			}
+
+			mockKeys.AssertExpectations(t)
 		})
 	}
 }
+
+func Test_clientInfo(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 54321}
+
+	testCases := []struct {
+		name  string
+		ctx   context.Context
+		label string
+		want  auth.ClientInfo
+	}{
+		{
+			name: "Peer, user agent and device label",
+			ctx: metadata.NewIncomingContext(peer.NewContext(context.Background(), &peer.Peer{Addr: addr}),
+				metadata.Pairs("user-agent", "zkp-prover grpc-go/1.65.0")),
+			label: "phone",
+			want:  auth.NewClientInfo("192.0.2.1:54321", "zkp-prover grpc-go/1.65.0", "phone"),
+		},
+		{
+			name: "Peer only",
+			ctx:  peer.NewContext(context.Background(), &peer.Peer{Addr: addr}),
+			want: auth.NewClientInfo("192.0.2.1:54321", "", ""),
+		},
+		{
+			name:  "Nothing known about the peer",
+			ctx:   context.Background(),
+			label: "phone",
+			want:  auth.NewClientInfo("", "", "phone"),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := clientInfo(tt.ctx, tt.label)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Peer over TLS", func(t *testing.T) {
+		t.Parallel()
+		serverInfo, _ := handshake(t, false)
+		got, err := clientInfo(peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: serverInfo}), "")
+		require.NoError(t, err)
+		require.Equal(t, "192.0.2.1:54321", got.PeerAddress())
+		require.Len(t, got.ChannelBinding(), channelBindingLength)
+		require.Empty(t, got.CertificateName())
+	})
+
+	t.Run("Peer with a client certificate", func(t *testing.T) {
+		t.Parallel()
+		serverInfo, _ := handshake(t, true)
+		got, err := clientInfo(peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: serverInfo}), "")
+		require.NoError(t, err)
+		require.Len(t, got.ChannelBinding(), channelBindingLength)
+		require.Equal(t, testcert.ClientName, got.CertificateName())
+	})
+}
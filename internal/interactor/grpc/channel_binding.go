@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// channelBindingLabel and channelBindingLength are the label and the length in bytes of the TLS exporter
+// value used as channel binding, as defined for tls-exporter by RFC 9266.
+const (
+	channelBindingLabel  = "EXPORTER-Channel-Binding"
+	channelBindingLength = 32
+)
+
+// channelBinding returns the tls-exporter channel binding (RFC 9266) of the connection described by info:
+// keying material exported from its TLS session (RFC 5705), the same on both ends of the connection and
+// different on any other. It returns nil for a connection without TLS.
+func channelBinding(info credentials.AuthInfo) ([]byte, error) {
+	tlsInfo, ok := info.(credentials.TLSInfo)
+	if !ok {
+		return nil, nil
+	}
+	binding, err := tlsInfo.State.ExportKeyingMaterial(channelBindingLabel, nil, channelBindingLength)
+	if err != nil {
+		return nil, fmt.Errorf("could not export channel binding: %w", err)
+	}
+	return binding, nil
+}
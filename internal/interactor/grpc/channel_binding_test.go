@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/testcert"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+)
+
+// handshake runs a TLS handshake between the server and client credentials over an in-memory connection and
+// returns what each end learns about it. With mutual set, the client presents the client certificate of
+// testcert, issued to testcert.ClientName, and the server verifies it.
+func handshake(t *testing.T, mutual bool) (server, client credentials.AuthInfo) {
+	t.Helper()
+	files, err := testcert.Write(t.TempDir())
+	require.NoError(t, err)
+	serverCfg := &config.Config{TLSCertFile: files.ServerCertFile, TLSKeyFile: files.ServerKeyFile}
+	clientCfg := &config.Config{TLSCAFile: files.CAFile}
+	if mutual {
+		serverCfg.TLSCAFile, serverCfg.TLSClientAuth = files.CAFile, true
+		clientCfg.TLSCertFile, clientCfg.TLSKeyFile = files.ClientCertFile, files.ClientKeyFile
+	}
+	serverCreds, err := ServerCredentials(serverCfg)
+	require.NoError(t, err)
+	clientCreds, err := clientCredentials(clientCfg)
+	require.NoError(t, err)
+
+	serverConn, clientConn := net.Pipe()
+	t.Cleanup(func() {
+		_ = serverConn.Close()
+		_ = clientConn.Close()
+	})
+
+	serverDone := make(chan error, 1)
+	go func() {
+		var serverErr error
+		_, server, serverErr = serverCreds.ServerHandshake(serverConn)
+		serverDone <- serverErr
+	}()
+	_, client, err = clientCreds.ClientHandshake(context.Background(), "localhost", clientConn)
+	require.NoError(t, err)
+	require.NoError(t, <-serverDone)
+	return server, client
+}
+
+func Test_channelBinding(t *testing.T) {
+	t.Parallel()
+
+	server, client := handshake(t, false)
+	serverBinding, err := channelBinding(server)
+	require.NoError(t, err)
+	clientBinding, err := channelBinding(client)
+	require.NoError(t, err)
+	require.Len(t, serverBinding, channelBindingLength)
+	require.Equal(t, serverBinding, clientBinding, "both ends of a connection should agree on its channel binding")
+
+	otherServer, _ := handshake(t, false)
+	otherBinding, err := channelBinding(otherServer)
+	require.NoError(t, err)
+	require.NotEqual(t, serverBinding, otherBinding, "another connection should have another channel binding")
+
+	insecureBinding, err := channelBinding(nil)
+	require.NoError(t, err)
+	require.Nil(t, insecureBinding, "a connection without TLS should have no channel binding")
+}
@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"practical-case-test/config"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	// ErrIncompleteKeyPair is returned when only one of the certificate and the key file is configured.
+	ErrIncompleteKeyPair = errors.New("TLS certificate and key files must be set together")
+	// ErrClientAuthWithoutCA is returned when client certificates are required without a CA file to check
+	// them against, or without the verifier serving TLS at all.
+	ErrClientAuthWithoutCA = errors.New("client certificate verification needs TLS and a CA file")
+	// ErrNoCACertificates is returned for a CA file that holds no PEM certificate.
+	ErrNoCACertificates = errors.New("no certificates found in CA file")
+)
+
+// ServerCredentials returns the transport credentials the verifier serves with. If cfg names a certificate
+// and key file, these are TLS credentials with that certificate, which with cfg.TLSClientAuth set also
+// require a client certificate signed by a CA of cfg.TLSCAFile. Otherwise they are insecure credentials.
+// It returns ErrIncompleteKeyPair, ErrClientAuthWithoutCA, ErrNoCACertificates or the error of reading
+// the files.
+func ServerCredentials(cfg *config.Config) (credentials.TransportCredentials, error) {
+	if cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" {
+		if cfg.TLSClientAuth {
+			return nil, ErrClientAuthWithoutCA
+		}
+		return insecure.NewCredentials(), nil
+	}
+
+	certificates, err := loadKeyPair(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{Certificates: certificates, MinVersion: tls.VersionTLS13}
+
+	if cfg.TLSClientAuth {
+		if cfg.TLSCAFile == "" {
+			return nil, ErrClientAuthWithoutCA
+		}
+		if tlsConfig.ClientCAs, err = loadCertPool(cfg.TLSCAFile); err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// clientCredentials returns the transport credentials the prover connects with. If cfg names a CA file or a
+// client certificate, these are TLS credentials that check the certificate of the verifier against the CAs
+// of cfg.TLSCAFile, or the system roots if it is empty, and present the client certificate if one is set.
+// Otherwise they are insecure credentials.
+// It returns ErrIncompleteKeyPair, ErrNoCACertificates or the error of reading the files.
+func clientCredentials(cfg *config.Config) (credentials.TransportCredentials, error) {
+	if cfg.TLSCAFile == "" && cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	certificates, err := loadKeyPair(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{Certificates: certificates, MinVersion: tls.VersionTLS13}
+
+	if cfg.TLSCAFile != "" {
+		if tlsConfig.RootCAs, err = loadCertPool(cfg.TLSCAFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// loadKeyPair loads the certificate and key files of cfg. It returns no certificate if neither is set, and
+// ErrIncompleteKeyPair if only one is.
+func loadKeyPair(cfg *config.Config) ([]tls.Certificate, error) {
+	if cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" {
+		return nil, nil
+	}
+	if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+		return nil, ErrIncompleteKeyPair
+	}
+	certificate, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	return []tls.Certificate{certificate}, nil
+}
+
+// loadCertPool returns a pool of the PEM certificates in the given file. It returns ErrNoCACertificates if the
+// file holds none.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, ErrNoCACertificates
+	}
+	return pool, nil
+}
+
+// certificateName returns the common name of the client certificate the verifier checked the connection
+// described by info against its CAs, or "" for a connection without a verified client certificate.
+func certificateName(info credentials.AuthInfo) string {
+	tlsInfo, ok := info.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+}
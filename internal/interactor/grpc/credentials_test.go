@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"practical-case-test/config"
+	"practical-case-test/internal/testcert"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerCredentials(t *testing.T) {
+	files, err := testcert.Write(t.TempDir())
+	require.NoError(t, err)
+	notPEM := filepath.Join(t.TempDir(), "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	tests := []struct {
+		name         string
+		cfg          *config.Config
+		wantProtocol string
+		wantErr      error
+	}{
+		{name: "Without TLS", cfg: &config.Config{}, wantProtocol: "insecure"},
+		{
+			name:         "TLS",
+			cfg:          &config.Config{TLSCertFile: files.ServerCertFile, TLSKeyFile: files.ServerKeyFile},
+			wantProtocol: "tls",
+		},
+		{
+			name: "Mutual TLS",
+			cfg: &config.Config{TLSCertFile: files.ServerCertFile, TLSKeyFile: files.ServerKeyFile,
+				TLSCAFile: files.CAFile, TLSClientAuth: true},
+			wantProtocol: "tls",
+		},
+		{name: "Certificate without key", cfg: &config.Config{TLSCertFile: files.ServerCertFile},
+			wantErr: ErrIncompleteKeyPair},
+		{name: "Client authentication without TLS", cfg: &config.Config{TLSCAFile: files.CAFile, TLSClientAuth: true},
+			wantErr: ErrClientAuthWithoutCA},
+		{
+			name:    "Client authentication without CA",
+			cfg:     &config.Config{TLSCertFile: files.ServerCertFile, TLSKeyFile: files.ServerKeyFile, TLSClientAuth: true},
+			wantErr: ErrClientAuthWithoutCA,
+		},
+		{
+			name: "CA file without certificates",
+			cfg: &config.Config{TLSCertFile: files.ServerCertFile, TLSKeyFile: files.ServerKeyFile,
+				TLSCAFile: notPEM, TLSClientAuth: true},
+			wantErr: ErrNoCACertificates,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			creds, err := ServerCredentials(tt.cfg)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantProtocol, creds.Info().SecurityProtocol)
+		})
+	}
+
+	_, err = ServerCredentials(&config.Config{TLSCertFile: files.ServerCertFile, TLSKeyFile: files.ClientKeyFile})
+	require.Error(t, err, "a key that does not match the certificate should be rejected")
+}
+
+func Test_clientCredentials(t *testing.T) {
+	files, err := testcert.Write(t.TempDir())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		cfg          *config.Config
+		wantProtocol string
+		wantErr      error
+	}{
+		{name: "Without TLS", cfg: &config.Config{}, wantProtocol: "insecure"},
+		{name: "TLS with a CA", cfg: &config.Config{TLSCAFile: files.CAFile}, wantProtocol: "tls"},
+		{
+			name:         "Mutual TLS",
+			cfg:          &config.Config{TLSCAFile: files.CAFile, TLSCertFile: files.ClientCertFile, TLSKeyFile: files.ClientKeyFile},
+			wantProtocol: "tls",
+		},
+		{
+			name:         "Client certificate with the system roots",
+			cfg:          &config.Config{TLSCertFile: files.ClientCertFile, TLSKeyFile: files.ClientKeyFile},
+			wantProtocol: "tls",
+		},
+		{name: "Key without certificate", cfg: &config.Config{TLSKeyFile: files.ClientKeyFile}, wantErr: ErrIncompleteKeyPair},
+		{name: "Missing CA file", cfg: &config.Config{TLSCAFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: os.ErrNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			creds, err := clientCredentials(tt.cfg)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantProtocol, creds.Info().SecurityProtocol)
+		})
+	}
+}
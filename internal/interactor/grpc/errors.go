@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"practical-case-test/internal/app"
+	"practical-case-test/internal/domain/auth"
+	"practical-case-test/internal/group"
+	"practical-case-test/internal/repository"
+	"practical-case-test/internal/repository/memory"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the google.rpc.ErrorInfo details the server sends with its errors.
+const errorDomain = "zkp-auth"
+
+// reasonInternal is the reason of errors that do not map to a domain error, and reasonInvalidElement the
+// one of an *app.InvalidElementError, whose ErrorInfo metadata names the value under metadataElement and
+// tells whether it was the identity under metadataIdentity.
+const (
+	reasonInternal       = "INTERNAL"
+	reasonInvalidElement = "INVALID_ELEMENT"
+	metadataElement      = "element"
+	metadataIdentity     = "identity"
+)
+
+// errorMapping maps a domain error to the gRPC status code and the ErrorInfo reason it is sent with.
+type errorMapping struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+// errorMappings lists the domain errors the server reports with a specific status code, in the order they
+// are checked. The client translates the reason of an error back into the domain error. Any other error is
+// reported as codes.Internal.
+var errorMappings = []errorMapping{
+	{err: auth.ErrInvalidUser, code: codes.InvalidArgument, reason: "INVALID_USER"},
+	{err: auth.ErrInvalidChallenge, code: codes.InvalidArgument, reason: "INVALID_CHALLENGE"},
+	{err: app.ErrInvalidSessionID, code: codes.InvalidArgument, reason: "INVALID_SESSION_ID"},
+	{err: app.ErrKDFCostTooHigh, code: codes.InvalidArgument, reason: "KDF_COST_TOO_HIGH"},
+	{err: memory.ErrUserAlreadyExists, code: codes.AlreadyExists, reason: "USER_ALREADY_EXISTS"},
+	{err: repository.ErrUserNotFound, code: codes.NotFound, reason: "USER_NOT_FOUND"},
+	{err: memory.ErrAuthIDNotFound, code: codes.NotFound, reason: "CHALLENGE_NOT_FOUND"},
+	{err: repository.ErrSessionNotFound, code: codes.NotFound, reason: "SESSION_NOT_FOUND"},
+	{err: app.ErrChallengeExpired, code: codes.DeadlineExceeded, reason: "CHALLENGE_EXPIRED"},
+	{err: app.ErrProofExpired, code: codes.DeadlineExceeded, reason: "PROOF_EXPIRED"},
+	{err: app.ErrInvalidResponse, code: codes.Unauthenticated, reason: "INVALID_RESPONSE"},
+	{err: app.ErrInvalidProof, code: codes.Unauthenticated, reason: "INVALID_PROOF"},
+	{err: repository.ErrProofReplayed, code: codes.Unauthenticated, reason: "PROOF_REPLAYED"},
+	{err: app.ErrSessionExpired, code: codes.Unauthenticated, reason: "SESSION_EXPIRED"},
+	{err: app.ErrUnauthenticated, code: codes.Unauthenticated, reason: "SESSION_NOT_VALID"},
+	{err: app.ErrPermissionDenied, code: codes.PermissionDenied, reason: "PERMISSION_DENIED"},
+	{err: app.ErrAdminCertificateRequired, code: codes.PermissionDenied, reason: "ADMIN_CERTIFICATE_REQUIRED"},
+	{err: ErrShuttingDown, code: codes.Unavailable, reason: "SHUTTING_DOWN"},
+	{err: context.DeadlineExceeded, code: codes.DeadlineExceeded, reason: "DEADLINE_EXCEEDED"},
+	{err: context.Canceled, code: codes.Canceled, reason: "CANCELED"},
+}
+
+// StatusError is an error that carries the gRPC status it is sent with, or was received as, together with
+// the domain error it stands for: errors.Is and errors.As see the domain error, and status.FromError the
+// status. The server returns them from its handlers and the client from its calls.
+type StatusError struct {
+	Status *status.Status
+	Err    error
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("rpc error: code = %s desc = %s", e.Status.Code(), e.Status.Message())
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the status of the error, which lets grpc send it as is.
+func (e *StatusError) GRPCStatus() *status.Status {
+	return e.Status
+}
+
+// toStatusError returns err as a *StatusError with the status code of the domain error it wraps according to
+// errorMappings, or codes.InvalidArgument for an *app.InvalidElementError, and the message of err. The status
+// carries an ErrorInfo naming the domain error by its reason and, for an invalid element, a BadRequest
+// naming the offending field.
+func toStatusError(err error) error {
+	code := codes.Internal
+	info := &errdetails.ErrorInfo{Reason: reasonInternal, Domain: errorDomain}
+	var badRequest *errdetails.BadRequest
+
+	var invalidElement *app.InvalidElementError
+	if errors.As(err, &invalidElement) {
+		code = codes.InvalidArgument
+		info.Reason = reasonInvalidElement
+		info.Metadata = map[string]string{
+			metadataElement:  invalidElement.Name,
+			metadataIdentity: strconv.FormatBool(errors.Is(invalidElement.Err, app.ErrIdentityElement)),
+		}
+		badRequest = &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: invalidElement.Name, Description: invalidElement.Error()},
+		}}
+	} else {
+		for _, m := range errorMappings {
+			if errors.Is(err, m.err) {
+				code, info.Reason = m.code, m.reason
+				break
+			}
+		}
+	}
+
+	st := status.New(code, err.Error())
+	withDetails, detailsErr := st.WithDetails(info)
+	if detailsErr == nil && badRequest != nil {
+		withDetails, detailsErr = withDetails.WithDetails(badRequest)
+	}
+	if detailsErr == nil {
+		st = withDetails
+	}
+	return &StatusError{Status: st, Err: err}
+}
+
+// fromStatusError translates an error returned by a call to the server back into the domain error named by
+// the ErrorInfo it carries, and returns it as a *StatusError together with the received status. It returns
+// err unchanged if it carries no ErrorInfo of errorDomain with a reason other than reasonInternal.
+func fromStatusError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detail := range st.Details() {
+		info, isInfo := detail.(*errdetails.ErrorInfo)
+		if !isInfo || info.GetDomain() != errorDomain {
+			continue
+		}
+		if domainErr := domainError(info); domainErr != nil {
+			return &StatusError{Status: st, Err: domainErr}
+		}
+	}
+	return err
+}
+
+// domainError returns the domain error named by the reason of info, or nil if the reason is unknown.
+func domainError(info *errdetails.ErrorInfo) error {
+	if info.GetReason() == reasonInvalidElement {
+		cause := group.ErrInvalidElement
+		if info.GetMetadata()[metadataIdentity] == strconv.FormatBool(true) {
+			cause = app.ErrIdentityElement
+		}
+		return &app.InvalidElementError{Name: info.GetMetadata()[metadataElement], Err: cause}
+	}
+	for _, m := range errorMappings {
+		if m.reason == info.GetReason() {
+			return m.err
+		}
+	}
+	return nil
+}
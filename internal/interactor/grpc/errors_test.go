@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"practical-case-test/internal/app"
+	"practical-case-test/internal/domain/auth"
+	"practical-case-test/internal/group"
+	"practical-case-test/internal/repository/memory"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// overTheWire returns the error a client receives for err returned by a handler.
+func overTheWire(err error) error {
+	st, _ := status.FromError(err)
+	return status.FromProto(st.Proto()).Err()
+}
+
+func Test_toStatusError(t *testing.T) {
+	errOther := errors.New("something broke")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason string
+		wantErr    error
+	}{
+		{name: "Invalid user", err: auth.ErrInvalidUser, wantCode: codes.InvalidArgument, wantReason: "INVALID_USER",
+			wantErr: auth.ErrInvalidUser},
+		{name: "Malformed session ID", err: app.ErrInvalidSessionID, wantCode: codes.InvalidArgument,
+			wantReason: "INVALID_SESSION_ID", wantErr: app.ErrInvalidSessionID},
+		{name: "User already exists", err: memory.ErrUserAlreadyExists, wantCode: codes.AlreadyExists,
+			wantReason: "USER_ALREADY_EXISTS", wantErr: memory.ErrUserAlreadyExists},
+		{name: "Unknown user", err: memory.ErrUserIDNotFound, wantCode: codes.NotFound, wantReason: "USER_NOT_FOUND",
+			wantErr: memory.ErrUserIDNotFound},
+		{name: "Unknown session", err: memory.ErrSessionNotFound, wantCode: codes.NotFound,
+			wantReason: "SESSION_NOT_FOUND", wantErr: memory.ErrSessionNotFound},
+		{name: "Expired challenge", err: app.ErrChallengeExpired, wantCode: codes.DeadlineExceeded,
+			wantReason: "CHALLENGE_EXPIRED", wantErr: app.ErrChallengeExpired},
+		{name: "Invalid s", err: app.ErrInvalidResponse, wantCode: codes.Unauthenticated,
+			wantReason: "INVALID_RESPONSE", wantErr: app.ErrInvalidResponse},
+		{name: "Invalid proof", err: app.ErrInvalidProof, wantCode: codes.Unauthenticated, wantReason: "INVALID_PROOF",
+			wantErr: app.ErrInvalidProof},
+		{name: "Permission denied", err: app.ErrPermissionDenied, wantCode: codes.PermissionDenied,
+			wantReason: "PERMISSION_DENIED", wantErr: app.ErrPermissionDenied},
+		{name: "Deadline", err: context.DeadlineExceeded, wantCode: codes.DeadlineExceeded,
+			wantReason: "DEADLINE_EXCEEDED", wantErr: context.DeadlineExceeded},
+		{name: "Other error", err: errOther, wantCode: codes.Internal, wantReason: reasonInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wrapped := fmt.Errorf("failed to do something: %w", tt.err)
+			err := toStatusError(wrapped)
+			require.ErrorIs(t, err, tt.err, "the server side should keep the original error")
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, tt.wantCode, st.Code())
+			require.Equal(t, wrapped.Error(), st.Message())
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			require.Equal(t, errorDomain, info.GetDomain())
+			require.Equal(t, tt.wantReason, info.GetReason())
+
+			received := fromStatusError(overTheWire(err))
+			require.Equal(t, tt.wantCode, status.Code(received))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, received, tt.wantErr)
+				require.Contains(t, received.Error(), wrapped.Error())
+			} else {
+				var statusErr *StatusError
+				require.False(t, errors.As(received, &statusErr), "an internal error should not be translated")
+			}
+		})
+	}
+}
+
+func Test_toStatusError_InvalidElement(t *testing.T) {
+	tests := []struct {
+		name  string
+		cause error
+	}{
+		{name: "Not in the group", cause: group.ErrInvalidElement},
+		{name: "Identity", cause: app.ErrIdentityElement},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := toStatusError(fmt.Errorf("user failed challenge: %w", &app.InvalidElementError{Name: "r1", Err: tt.cause}))
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+			st, _ := status.FromError(err)
+			require.Len(t, st.Details(), 2)
+			badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+			require.True(t, ok)
+			require.Equal(t, "r1", badRequest.GetFieldViolations()[0].GetField())
+
+			var invalidElement *app.InvalidElementError
+			received := fromStatusError(overTheWire(err))
+			require.ErrorAs(t, received, &invalidElement)
+			require.Equal(t, "r1", invalidElement.Name)
+			require.ErrorIs(t, received, tt.cause)
+		})
+	}
+}
+
+func Test_fromStatusError(t *testing.T) {
+	t.Parallel()
+	plain := errors.New("connection refused")
+	require.Equal(t, plain, fromStatusError(plain))
+
+	withoutDetails := status.Error(codes.Unavailable, "unavailable")
+	require.Equal(t, withoutDetails, fromStatusError(withoutDetails))
+
+	st, err := status.New(codes.NotFound, "not found").WithDetails(&errdetails.ErrorInfo{Reason: "USER_NOT_FOUND",
+		Domain: "elsewhere"})
+	require.NoError(t, err)
+	require.Equal(t, st.Err(), fromStatusError(st.Err()), "details of another domain should be ignored")
+}
@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// pinger is the part of the repository the health checks need.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// StartHealthChecker keeps the statuses hs reports through the grpc.health.v1 service up to date, both for
+// the server as a whole, the empty service name, and for the Auth service. They are SERVING while the group
+// parameters of cfg pass Config.Validate, which is checked once, and ar answers Ping, which is checked every
+// cfg.HealthCheckInterval with that interval as timeout, and NOT_SERVING otherwise. Once ctx is done, every
+// status turns NOT_SERVING for good, so that load balancers stop sending calls while the server drains.
+func StartHealthChecker(ctx context.Context, hs *health.Server, cfg *config.Config, ar pinger) {
+	validateErr := cfg.Validate()
+	if validateErr != nil {
+		slog.Error("health: invalid group parameters", "error", validateErr)
+	}
+
+	var last healthpb.HealthCheckResponse_ServingStatus
+	check := func() {
+		status := healthpb.HealthCheckResponse_SERVING
+		if validateErr != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		} else {
+			pingCtx, cancel := context.WithTimeout(ctx, cfg.HealthCheckInterval)
+			if err := ar.Ping(pingCtx); err != nil && ctx.Err() == nil {
+				slog.Error("health: repository unavailable", "error", err)
+				status = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+			cancel()
+		}
+		if status != last {
+			slog.Info("health status changed", "status", status)
+			last = status
+		}
+		hs.SetServingStatus("", status)
+		hs.SetServingStatus(interactor.Auth_ServiceDesc.ServiceName, status)
+	}
+	check()
+
+	ticker := time.NewTicker(cfg.HealthCheckInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				hs.Shutdown()
+				return
+			case <-ticker.C:
+				check()
+			}
+		}
+	}()
+}
@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakePinger is a repository whose availability the test switches.
+type fakePinger struct {
+	err atomic.Pointer[error]
+}
+
+func (p *fakePinger) Ping(context.Context) error {
+	if err := p.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func TestStartHealthChecker(t *testing.T) {
+	valid := &config.Config{G: big.NewInt(4), H: big.NewInt(9), P: big.NewInt(23), Q: big.NewInt(11),
+		HealthCheckInterval: 10 * time.Millisecond}
+	invalid := *valid
+	invalid.H = invalid.G
+
+	statusOf := func(hs *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+		res, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return res.GetStatus()
+	}
+	serving := func(hs *health.Server, want healthpb.HealthCheckResponse_ServingStatus) func() bool {
+		return func() bool {
+			return statusOf(hs, "") == want && statusOf(hs, interactor.Auth_ServiceDesc.ServiceName) == want
+		}
+	}
+
+	t.Run("Follows the repository", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		hs, ar := health.NewServer(), new(fakePinger)
+
+		StartHealthChecker(ctx, hs, valid, ar)
+		require.True(t, serving(hs, healthpb.HealthCheckResponse_SERVING)(), "a healthy verifier should be serving")
+
+		unavailable := errors.New("repository unavailable")
+		ar.err.Store(&unavailable)
+		require.Eventually(t, serving(hs, healthpb.HealthCheckResponse_NOT_SERVING), time.Second, 5*time.Millisecond)
+		ar.err.Store(nil)
+		require.Eventually(t, serving(hs, healthpb.HealthCheckResponse_SERVING), time.Second, 5*time.Millisecond)
+
+		cancel()
+		require.Eventually(t, serving(hs, healthpb.HealthCheckResponse_NOT_SERVING), time.Second, 5*time.Millisecond,
+			"a verifier shutting down should not be serving")
+	})
+
+	t.Run("Invalid group parameters", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		hs := health.NewServer()
+
+		StartHealthChecker(ctx, hs, &invalid, new(fakePinger))
+		require.True(t, serving(hs, healthpb.HealthCheckResponse_NOT_SERVING)())
+	})
+}
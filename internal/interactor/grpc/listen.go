@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"practical-case-test/config"
+)
+
+// unixScheme prefixes addresses of Unix domain sockets, as in the unix:///path targets of gRPC.
+const unixScheme = "unix:"
+
+// socketProbeTimeout bounds how long Listen tries to connect to an existing socket to tell whether a verifier
+// still serves on it.
+const socketProbeTimeout = time.Second
+
+// ErrSocketInUse is returned by Listen when another process still accepts connections on the socket.
+var ErrSocketInUse = errors.New("socket is in use")
+
+// Listen opens the listener of the verifier on cfg.ListenAddress: a TCP host:port, where port 0 picks a free
+// port, or unix:///path (or unix:path for a relative path) for a Unix domain socket. A socket is created with
+// the permissions cfg.ListenSocketMode and removed again when the listener is closed. A socket left behind by
+// a verifier that did not shut down cleanly, which refuses connections, is replaced; Listen returns
+// ErrSocketInUse if a process still accepts connections on the socket, and leaves any other file at the path
+// alone.
+func Listen(cfg *config.Config) (net.Listener, error) {
+	path, ok := strings.CutPrefix(cfg.ListenAddress, unixScheme)
+	if !ok {
+		listener, err := net.Listen("tcp", cfg.ListenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", cfg.ListenAddress, err)
+		}
+		return listener, nil
+	}
+
+	path = strings.TrimPrefix(path, "//")
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	return listenUnix(path, cfg.ListenSocketMode)
+}
+
+// removeStaleSocket removes the socket at path if nothing accepts connections on it anymore. It returns
+// ErrSocketInUse if a process does, and an error if path is a file other than a socket.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to inspect %s: %w", path, err)
+	case info.Mode().Type() != fs.ModeSocket:
+		return fmt.Errorf("failed to listen on %s: %w", path, fs.ErrExist)
+	}
+
+	conn, err := net.DialTimeout("unix", path, socketProbeTimeout)
+	if err == nil {
+		return errors.Join(fmt.Errorf("failed to listen on %s: %w", path, ErrSocketInUse), conn.Close())
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("failed to probe socket %s: %w", path, err)
+	}
+	if err = os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// listenUnix listens on a new Unix domain socket at path with the permissions mode. The socket is created in a
+// new directory next to path that only the verifier can enter, given its mode there and then moved to path,
+// so that it is never reachable with the permissions of the umask.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".zkp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a directory for socket %s: %w", path, err)
+	}
+	defer os.RemoveAll(dir)
+
+	created := filepath.Join(dir, "s")
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: created, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	listener.SetUnlinkOnClose(false)
+	if err = os.Chmod(created, mode); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to set the mode of socket %s: %w", path, err), listener.Close())
+	}
+	if err = os.Rename(created, path); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to move socket to %s: %w", path, err), listener.Close())
+	}
+	return &unixListener{UnixListener: listener, path: path}, nil
+}
+
+// unixListener is a listener on the Unix domain socket at path, which it reports as its address and removes
+// when it is closed.
+type unixListener struct {
+	*net.UnixListener
+	path   string
+	unlink sync.Once
+}
+
+// Addr returns the path of the socket.
+func (l *unixListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: l.path, Net: "unix"}
+}
+
+// Close stops listening and removes the socket, the first time it is called.
+func (l *unixListener) Close() error {
+	err := l.UnixListener.Close()
+	l.unlink.Do(func() {
+		if rmErr := os.Remove(l.path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			err = errors.Join(err, rmErr)
+		}
+	})
+	return err
+}
+
+// DialAddress returns the address a prover reaches listener at, in the forms VerifierURL accepts.
+func DialAddress(listener net.Listener) string {
+	addr := listener.Addr()
+	switch {
+	case addr.Network() != "unix":
+		return addr.String()
+	case filepath.IsAbs(addr.String()):
+		return unixScheme + "//" + addr.String()
+	default:
+		return unixScheme + addr.String()
+	}
+}
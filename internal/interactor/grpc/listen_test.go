@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/pkg/sessionjwt"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestListen_TCP(t *testing.T) {
+	t.Parallel()
+	listener, err := Listen(&config.Config{ListenAddress: "127.0.0.1:0"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, listener.Close())
+	})
+
+	address := DialAddress(listener)
+	require.True(t, strings.HasPrefix(address, "127.0.0.1:"), address)
+	require.NotEqual(t, "127.0.0.1:0", address, "port 0 should pick a free port")
+
+	_, err = Listen(&config.Config{ListenAddress: address})
+	require.Error(t, err, "a port in use should be refused")
+}
+
+func TestListen_Unix(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "verifier.sock")
+
+	// A socket left behind by a verifier that did not shut down cleanly.
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	unixListener, ok := stale.(*net.UnixListener)
+	require.True(t, ok)
+	unixListener.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	listener, err := Listen(&config.Config{ListenAddress: "unix://" + path, ListenSocketMode: 0o600})
+	require.NoError(t, err)
+	require.Equal(t, "unix://"+path, DialAddress(listener))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.ModeSocket, info.Mode().Type())
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "only the socket should be left in its directory")
+
+	require.NoError(t, listener.Close())
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist, "closing the listener should remove the socket")
+
+	notSocket := filepath.Join(t.TempDir(), "verifier.sock")
+	require.NoError(t, os.WriteFile(notSocket, []byte("keep me"), 0o600))
+	_, err = Listen(&config.Config{ListenAddress: "unix:" + notSocket, ListenSocketMode: 0o600})
+	require.Error(t, err, "a file that is not a socket should not be replaced")
+	content, err := os.ReadFile(notSocket)
+	require.NoError(t, err)
+	require.Equal(t, "keep me", string(content))
+}
+
+func TestListen_UnixInUse(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{ListenAddress: "unix://" + filepath.Join(t.TempDir(), "verifier.sock"), ListenSocketMode: 0o600}
+	listener, err := Listen(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, listener.Close())
+	})
+
+	_, err = Listen(cfg)
+	require.ErrorIs(t, err, ErrSocketInUse, "a socket a verifier still serves on should not be taken over")
+
+	conn, err := net.Dial("unix", listener.Addr().String())
+	require.NoError(t, err, "the first verifier should keep its socket")
+	require.NoError(t, conn.Close())
+}
+
+func TestListen_ServeOverUnixSocket(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{ListenAddress: "unix://" + filepath.Join(t.TempDir(), "verifier.sock"), ListenSocketMode: 0o600}
+	listener, err := Listen(cfg)
+	require.NoError(t, err)
+
+	keys := new(MockGetPublicKeys)
+	keys.On("Exec", mock.Anything, mock.Anything).Return(&sessionjwt.KeySet{Keys: []sessionjwt.JWK{}}, nil)
+	server := grpc.NewServer()
+	interactor.RegisterAuthServer(server, NewAuthenticationServer(cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, keys))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	client, err := NewClient(DialAddress(listener), &config.Config{}, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	got, err := client.PublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, got.Keys)
+	keys.AssertExpectations(t)
+}
@@ -9,8 +9,8 @@ import (
 	"practical-case-test/internal/app"
 	"practical-case-test/internal/domain/auth"
 	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/pkg/sessionjwt"
 
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
 )
@@ -19,8 +19,8 @@ type MockCreateAuthenticationChallenge struct {
 	mock.Mock
 }
 
-func (m *MockCreateAuthenticationChallenge) Exec(context.Context, *interactor.AuthenticationChallengeRequest) (
-	*auth.Challenge, error) {
+func (m *MockCreateAuthenticationChallenge) Exec(context.Context, *config.Config, *interactor.AuthenticationChallengeRequest,
+	auth.ClientInfo) (*auth.Challenge, error) {
 	args := m.Called()
 	return args.Get(0).(*auth.Challenge), args.Error(1)
 }
@@ -29,25 +29,118 @@ type MockRegisterUser struct {
 	mock.Mock
 }
 
-func (m *MockRegisterUser) Exec(ctx context.Context, req *interactor.RegisterRequest) error {
+func (m *MockRegisterUser) Exec(ctx context.Context, _ *config.Config, req *interactor.RegisterRequest,
+	client auth.ClientInfo) error {
+	args := m.Called(ctx, req, client)
+	return args.Error(0)
+}
+
+type MockGetSalt struct {
+	mock.Mock
+}
+
+func (m *MockGetSalt) Exec(ctx context.Context, _ *config.Config, req *interactor.SaltRequest) ([]byte, auth.KDFParams,
+	error) {
+	args := m.Called(ctx, req)
+	salt, _ := args.Get(0).([]byte)
+	kdf, _ := args.Get(1).(auth.KDFParams)
+	return salt, kdf, args.Error(2)
+}
+
+type MockValidateSession struct {
+	mock.Mock
+}
+
+func (m *MockValidateSession) Exec(ctx context.Context, _ *config.Config, req *interactor.ValidateSessionRequest) (
+	*app.SessionStatus, error) {
+	args := m.Called(ctx, req)
+	status, _ := args.Get(0).(*app.SessionStatus)
+	return status, args.Error(1)
+}
+
+type MockRefreshSession struct {
+	mock.Mock
+}
+
+func (m *MockRefreshSession) Exec(ctx context.Context, _ *config.Config, req *interactor.RefreshSessionRequest) (
+	*app.SessionStatus, error) {
+	args := m.Called(ctx, req)
+	status, _ := args.Get(0).(*app.SessionStatus)
+	return status, args.Error(1)
+}
+
+type MockLogout struct {
+	mock.Mock
+}
+
+func (m *MockLogout) Exec(ctx context.Context, _ *config.Config, req *interactor.LogoutRequest) error {
 	args := m.Called(ctx, req)
 	return args.Error(0)
 }
 
+type MockListSessions struct {
+	mock.Mock
+}
+
+func (m *MockListSessions) Exec(ctx context.Context, _ *config.Config, req *interactor.ListSessionsRequest,
+	client auth.ClientInfo) ([]app.SessionStatus, error) {
+	args := m.Called(ctx, req, client)
+	sessions, _ := args.Get(0).([]app.SessionStatus)
+	return sessions, args.Error(1)
+}
+
+type MockRevokeAllSessions struct {
+	mock.Mock
+}
+
+func (m *MockRevokeAllSessions) Exec(ctx context.Context, _ *config.Config, req *interactor.RevokeAllSessionsRequest,
+	client auth.ClientInfo) (int, error) {
+	args := m.Called(ctx, req, client)
+	return args.Int(0), args.Error(1)
+}
+
+type MockGetPublicKeys struct {
+	mock.Mock
+}
+
+func (m *MockGetPublicKeys) Exec(ctx context.Context, _ *config.Config, req *interactor.GetPublicKeysRequest) (
+	*sessionjwt.KeySet, error) {
+	args := m.Called(ctx, req)
+	keys, _ := args.Get(0).(*sessionjwt.KeySet)
+	return keys, args.Error(1)
+}
+
 type MockVerifyAuthExecuterSuccess struct{}
 
 func (m *MockVerifyAuthExecuterSuccess) Exec(_ context.Context, _ *config.Config,
-	_ *interactor.AuthenticationAnswerRequest) (*auth.Session, error) {
-	session, _ := auth.NewSession(uuid.New(), "userId", 1234)
-	return session, nil
+	_ *interactor.AuthenticationAnswerRequest) (*app.IssuedSession, error) {
+	session, _ := auth.NewSession(auth.SessionHash{1}, "userId", 1234)
+	return &app.IssuedSession{Session: session, Token: "token", SignedToken: "signed-token"}, nil
 }
 
 type MockVerifyAuthExecuterFail struct{}
 
-func (m *MockVerifyAuthExecuterFail) Exec(_ context.Context, _ *config.Config, _ *interactor.AuthenticationAnswerRequest) (*auth.Session, error) {
+func (m *MockVerifyAuthExecuterFail) Exec(_ context.Context, _ *config.Config, _ *interactor.AuthenticationAnswerRequest) (
+	*app.IssuedSession, error) {
 	return nil, errors.New("authentication failed")
 }
 
+type MockLoginNonInteractiveSuccess struct{}
+
+func (m *MockLoginNonInteractiveSuccess) Exec(_ context.Context, _ *config.Config,
+	_ *interactor.NonInteractiveLoginRequest, client auth.ClientInfo) (*app.IssuedSession, error) {
+	session, _ := auth.NewSession(auth.SessionHash{1}, "userId", 1234)
+	*session = session.WithClient(client)
+	return &app.IssuedSession{Session: session, Token: "token", SignedToken: "signed-token"}, nil
+}
+
+type MockLoginNonInteractiveFail struct{}
+
+func (m *MockLoginNonInteractiveFail) Exec(_ context.Context, _ *config.Config,
+	_ *interactor.NonInteractiveLoginRequest, _ auth.ClientInfo) (*app.IssuedSession, error) {
+	return nil, app.ErrInvalidProof
+}
+
 type MockAuthClient struct {
 	RegisterResponse                *interactor.RegisterResponse
 	RegisterError                   error
@@ -55,6 +148,21 @@ type MockAuthClient struct {
 	AuthenticationChallengeError    error
 	AuthenticationAnswerResponse    *interactor.AuthenticationAnswerResponse
 	AuthenticationAnswerError       error
+	NonInteractiveLoginResponse     *interactor.NonInteractiveLoginResponse
+	NonInteractiveLoginError        error
+	SaltResponse                    *interactor.SaltResponse
+	SaltError                       error
+	ValidateSessionResponse         *interactor.ValidateSessionResponse
+	ValidateSessionError            error
+	RefreshSessionResponse          *interactor.RefreshSessionResponse
+	RefreshSessionError             error
+	LogoutError                     error
+	ListSessionsResponse            *interactor.ListSessionsResponse
+	ListSessionsError               error
+	RevokeAllSessionsResponse       *interactor.RevokeAllSessionsResponse
+	RevokeAllSessionsError          error
+	GetPublicKeysResponse           *interactor.GetPublicKeysResponse
+	GetPublicKeysError              error
 }
 
 func (m *MockAuthClient) Register(_ context.Context, _ *interactor.RegisterRequest, _ ...grpc.CallOption) (*interactor.RegisterResponse,
@@ -62,6 +170,11 @@ func (m *MockAuthClient) Register(_ context.Context, _ *interactor.RegisterReque
 	return m.RegisterResponse, m.RegisterError
 }
 
+func (m *MockAuthClient) GetSalt(_ context.Context, _ *interactor.SaltRequest, _ ...grpc.CallOption) (*interactor.SaltResponse,
+	error) {
+	return m.SaltResponse, m.SaltError
+}
+
 func (m *MockAuthClient) CreateAuthenticationChallenge(_ context.Context, _ *interactor.AuthenticationChallengeRequest,
 	_ ...grpc.CallOption) (*interactor.AuthenticationChallengeResponse, error) {
 	return m.AuthenticationChallengeResponse, m.AuthenticationChallengeError
@@ -72,6 +185,44 @@ func (m *MockAuthClient) VerifyAuthentication(_ context.Context, _ *interactor.A
 	return m.AuthenticationAnswerResponse, m.AuthenticationAnswerError
 }
 
+func (m *MockAuthClient) LoginNonInteractive(_ context.Context, _ *interactor.NonInteractiveLoginRequest,
+	_ ...grpc.CallOption) (*interactor.NonInteractiveLoginResponse, error) {
+	return m.NonInteractiveLoginResponse, m.NonInteractiveLoginError
+}
+
+func (m *MockAuthClient) ValidateSession(_ context.Context, _ *interactor.ValidateSessionRequest,
+	_ ...grpc.CallOption) (*interactor.ValidateSessionResponse, error) {
+	return m.ValidateSessionResponse, m.ValidateSessionError
+}
+
+func (m *MockAuthClient) RefreshSession(_ context.Context, _ *interactor.RefreshSessionRequest,
+	_ ...grpc.CallOption) (*interactor.RefreshSessionResponse, error) {
+	return m.RefreshSessionResponse, m.RefreshSessionError
+}
+
+func (m *MockAuthClient) Logout(_ context.Context, _ *interactor.LogoutRequest,
+	_ ...grpc.CallOption) (*interactor.LogoutResponse, error) {
+	if m.LogoutError != nil {
+		return nil, m.LogoutError
+	}
+	return &interactor.LogoutResponse{}, nil
+}
+
+func (m *MockAuthClient) ListSessions(_ context.Context, _ *interactor.ListSessionsRequest,
+	_ ...grpc.CallOption) (*interactor.ListSessionsResponse, error) {
+	return m.ListSessionsResponse, m.ListSessionsError
+}
+
+func (m *MockAuthClient) RevokeAllSessions(_ context.Context, _ *interactor.RevokeAllSessionsRequest,
+	_ ...grpc.CallOption) (*interactor.RevokeAllSessionsResponse, error) {
+	return m.RevokeAllSessionsResponse, m.RevokeAllSessionsError
+}
+
+func (m *MockAuthClient) GetPublicKeys(_ context.Context, _ *interactor.GetPublicKeysRequest,
+	_ ...grpc.CallOption) (*interactor.GetPublicKeysResponse, error) {
+	return m.GetPublicKeysResponse, m.GetPublicKeysError
+}
+
 type MockRegisterExecuter struct {
 	Y1      *big.Int
 	Y2      *big.Int
@@ -96,6 +247,26 @@ type MockComputeSExecuter struct {
 	Err    error
 }
 
-func (m *MockComputeSExecuter) Exec(_ *config.Config, _, _ *big.Int, _ *interactor.AuthenticationChallengeResponse) (*big.Int, error) {
+func (m *MockComputeSExecuter) Exec(_ *config.Config, _, _ *big.Int, _ *interactor.AuthenticationChallengeResponse,
+	_ []byte) (*big.Int, error) {
+	return m.Result, m.Err
+}
+
+type MockProveNonInteractiveExecuter struct {
+	Result *app.NonInteractiveProof
+	Err    error
+}
+
+func (m *MockProveNonInteractiveExecuter) Exec(_ *config.Config, _ string, _ *big.Int, _ int64, _ []byte) (
+	*app.NonInteractiveProof, error) {
+	return m.Result, m.Err
+}
+
+type MockDeriveSecretExecuter struct {
+	Result *big.Int
+	Err    error
+}
+
+func (m *MockDeriveSecretExecuter) Exec(_ *config.Config, _ string, _ []byte, _ auth.KDFParams) (*big.Int, error) {
 	return m.Result, m.Err
 }
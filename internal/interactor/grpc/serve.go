@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+
+	"google.golang.org/grpc"
+)
+
+// ErrShutdownTimeout is returned by Serve when in-flight calls did not finish within the grace period and
+// were cut off.
+var ErrShutdownTimeout = errors.New("in-flight calls did not finish within the grace period")
+
+// ErrShuttingDown is returned for the calls a Drain refuses while the verifier shuts down.
+var ErrShuttingDown = errors.New("verifier is shutting down")
+
+// drainPollInterval is how often a Drain checks whether challenges are still waiting for their answer.
+const drainPollInterval = 100 * time.Millisecond
+
+// drainShare is the share of the grace period Serve waits at most for the answers to pending challenges, so
+// that abandoned challenges, which stay pending until they expire, leave the rest of the grace period to the
+// calls in flight.
+const drainShare = 2
+
+// drainRefused are the calls a Drain refuses: those that start a new login.
+var drainRefused = map[string]bool{
+	interactor.Auth_CreateAuthenticationChallenge_FullMethodName: true,
+	interactor.Auth_LoginNonInteractive_FullMethodName:           true,
+}
+
+// challengeCounter is the part of the repository a Drain needs.
+type challengeCounter interface {
+	CountChallenges(ctx context.Context, issuedSince int64) (int, error)
+}
+
+// Drain keeps the interactive logins that got their challenge before the verifier was asked to stop alive
+// during the grace period of Serve: once started, it refuses the calls that start a new login, and Serve waits
+// for the answers to the challenges already issued before it stops the server. Every other call, such as
+// VerifyAuthentication, session management and health checks, passes.
+type Drain struct {
+	cfg      *config.Config
+	ar       challengeCounter
+	draining atomic.Bool
+}
+
+// NewDrain returns a Drain for the challenges of ar, which can be answered for cfg.ChallengeTTL.
+func NewDrain(cfg *config.Config, ar challengeCounter) *Drain {
+	return &Drain{cfg: cfg, ar: ar}
+}
+
+// UnaryInterceptor is the grpc.UnaryServerInterceptor of the Drain. Once the drain has started, it answers
+// CreateAuthenticationChallenge and LoginNonInteractive with ErrShuttingDown, as codes.Unavailable, so that the
+// client logs in with another verifier.
+func (d *Drain) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	if d.draining.Load() && drainRefused[info.FullMethod] {
+		return nil, toStatusError(ErrShuttingDown)
+	}
+	return handler(ctx, req)
+}
+
+// wait starts the drain and waits until no challenge issued within cfg.ChallengeTTL is left to answer, until
+// ctx is done or until the challenges cannot be counted. Challenges still pending then are abandoned.
+func (d *Drain) wait(ctx context.Context) {
+	d.draining.Store(true)
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		pending, err := d.ar.CountChallenges(ctx, time.Now().Add(-d.cfg.ChallengeTTL).Unix())
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			slog.Error("failed to count pending challenges", "error", err)
+			return
+		case pending == 0:
+			return
+		}
+		slog.Info("waiting for pending logins", "challenges", pending)
+		select {
+		case <-ctx.Done():
+			slog.Info("stopped waiting for pending logins", "challenges", pending)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Serve serves server on listener until ctx is done, typically because the verifier received a termination
+// signal, and then shuts the server down gracefully within gracePeriod. With a drain, which must intercept the
+// calls of server, it first keeps serving the answers to the challenges already issued, for at most half of
+// gracePeriod: challenges left unanswered then are abandoned, which is not a failure. It then stops accepting
+// connections and calls and waits for the calls in flight to finish. A nil drain skips the first step. A zero
+// gracePeriod stops the server at once instead, cutting off any calls in flight.
+//
+// It returns the deadline by which the whole shutdown, including any clean-up of the caller, has to be done, or
+// the zero time for a zero gracePeriod, which sets no deadline. Its error is nil once the calls in flight all
+// finished, ErrShutdownTimeout if calls had to be cut off after the grace period, or the error of
+// grpc.Server.Serve if the server failed before ctx was done.
+func Serve(ctx context.Context, server *grpc.Server, listener net.Listener, gracePeriod time.Duration,
+	drain *Drain) (time.Time, error) {
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return shutdownDeadline(gracePeriod), err
+	case <-ctx.Done():
+	}
+
+	deadline := shutdownDeadline(gracePeriod)
+	if gracePeriod == 0 {
+		slog.Info("shutting down immediately")
+		server.Stop()
+		return deadline, stopped(<-served)
+	}
+
+	slog.Info("shutting down, draining in-flight calls", "grace period", gracePeriod)
+	if drain != nil {
+		drainCtx, cancel := context.WithTimeout(context.Background(), gracePeriod/drainShare)
+		drain.wait(drainCtx)
+		cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case <-done:
+		return deadline, stopped(<-served)
+	case <-timer.C:
+		// Stop closes the connections left and cancels the calls on them. It is not waited for, since it
+		// cannot complete while GracefulStop still waits for handlers that ignore the cancellation.
+		go server.Stop()
+		return deadline, ErrShutdownTimeout
+	}
+}
+
+// shutdownDeadline returns the time gracePeriod from now, or the zero time for a zero gracePeriod.
+func shutdownDeadline(gracePeriod time.Duration) time.Time {
+	if gracePeriod == 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(gracePeriod)
+}
+
+// stopped returns the error of grpc.Server.Serve for a server that was asked to stop: nil if it stopped before
+// it got to serve, which it reports as grpc.ErrServerStopped, and err otherwise.
+func stopped(err error) error {
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+	return err
+}
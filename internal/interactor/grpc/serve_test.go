@@ -0,0 +1,257 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"practical-case-test/config"
+	interactor "practical-case-test/internal/interactor/proto"
+	"practical-case-test/pkg/sessionjwt"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// blockingServer starts serving an AuthenticationServer whose GetPublicKeys calls block until release is
+// closed, reporting on entered when they do, with Serve and the given grace period. It returns a client for
+// the server, the function that stops serving and the channel Serve returns its error on.
+func blockingServer(t *testing.T, gracePeriod time.Duration, entered chan<- struct{}, release <-chan struct{}) (
+	*AuthenticationClient, context.CancelFunc, <-chan error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	keys := new(MockGetPublicKeys)
+	keys.On("Exec", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		entered <- struct{}{}
+		<-release
+	}).Return(&sessionjwt.KeySet{Keys: []sessionjwt.JWK{}}, nil)
+	server := grpc.NewServer()
+	interactor.RegisterAuthServer(server, NewAuthenticationServer(&config.Config{}, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, keys))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	served := make(chan error, 1)
+	go func() {
+		_, err := Serve(ctx, server, listener, gracePeriod, nil)
+		served <- err
+	}()
+
+	client, err := NewClient(listener.Addr().String(), &config.Config{}, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+	return client, cancel, served
+}
+
+func TestServe_DrainsInFlightCalls(t *testing.T) {
+	t.Parallel()
+	entered, release := make(chan struct{}, 1), make(chan struct{})
+	client, stop, served := blockingServer(t, time.Minute, entered, release)
+
+	inFlight := make(chan error, 1)
+	go func() {
+		_, err := client.PublicKeys(context.Background())
+		inFlight <- err
+	}()
+	<-entered
+
+	stop()
+	select {
+	case err := <-served:
+		t.Fatalf("Serve returned %v before the in-flight call finished", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-inFlight, "the in-flight call should finish")
+	require.NoError(t, <-served)
+}
+
+func TestServe_CutsOffCallsAfterGracePeriod(t *testing.T) {
+	t.Parallel()
+	entered, release := make(chan struct{}, 1), make(chan struct{})
+	defer close(release)
+	client, stop, served := blockingServer(t, 50*time.Millisecond, entered, release)
+
+	inFlight := make(chan error, 1)
+	go func() {
+		_, err := client.PublicKeys(context.Background())
+		inFlight <- err
+	}()
+	<-entered
+
+	stop()
+	require.ErrorIs(t, <-served, ErrShutdownTimeout)
+	require.Equal(t, codes.Unavailable, status.Code(<-inFlight), "the in-flight call should be cut off")
+}
+
+func TestServe_StopsAtOnceWithoutGracePeriod(t *testing.T) {
+	t.Parallel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	deadline, err := Serve(ctx, grpc.NewServer(), listener, 0, NewDrain(&config.Config{}, new(pendingChallenges)))
+	require.NoError(t, err, "an idle server should stop cleanly")
+	require.True(t, deadline.IsZero(), "a zero grace period should set no deadline")
+}
+
+func TestServe_ReturnsDeadline(t *testing.T) {
+	t.Parallel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	before := time.Now()
+	deadline, err := Serve(ctx, grpc.NewServer(), listener, time.Minute, nil)
+	require.NoError(t, err)
+	require.WithinRange(t, deadline, before.Add(time.Minute), time.Now().Add(time.Minute),
+		"the deadline should be the end of the grace period")
+}
+
+func TestServe_Fails(t *testing.T) {
+	t.Parallel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+
+	_, err = Serve(context.Background(), grpc.NewServer(), listener, time.Second, nil)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrShutdownTimeout)
+}
+
+// pendingChallenges is a challengeCounter that reports a fixed number of pending challenges.
+type pendingChallenges struct {
+	count atomic.Int64
+}
+
+func (p *pendingChallenges) CountChallenges(context.Context, int64) (int, error) {
+	return int(p.count.Load()), nil
+}
+
+// drainingServer starts serving an AuthenticationServer that answers challenges, non-interactive logins and
+// public key requests at once, with Serve, the given grace period and a Drain over pending. It returns a client for the server, the
+// function that stops serving and the channel Serve returns its error on.
+func drainingServer(t *testing.T, gracePeriod time.Duration, pending *pendingChallenges) (
+	interactor.AuthClient, context.CancelFunc, <-chan error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	keys := new(MockGetPublicKeys)
+	keys.On("Exec", mock.Anything, mock.Anything).Return(&sessionjwt.KeySet{Keys: []sessionjwt.JWK{}}, nil)
+	drain := NewDrain(&config.Config{ChallengeTTL: time.Minute}, pending)
+	server := grpc.NewServer(grpc.UnaryInterceptor(drain.UnaryInterceptor))
+	interactor.RegisterAuthServer(server, NewAuthenticationServer(&config.Config{}, nil, nil,
+		&MockVerifyAuthExecuterSuccess{}, &MockLoginNonInteractiveSuccess{}, nil, nil, nil, nil, nil, nil, keys))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	served := make(chan error, 1)
+	go func() {
+		_, err := Serve(ctx, server, listener, gracePeriod, drain)
+		served <- err
+	}()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	return interactor.NewAuthClient(conn), cancel, served
+}
+
+func TestServe_DrainsPendingLogins(t *testing.T) {
+	t.Parallel()
+	pending := new(pendingChallenges)
+	pending.count.Store(1)
+	client, stop, served := drainingServer(t, time.Minute, pending)
+	login := &interactor.NonInteractiveLoginRequest{User: "user"}
+	_, err := client.LoginNonInteractive(context.Background(), login)
+	require.NoError(t, err)
+
+	stop()
+	require.Eventually(t, func() bool {
+		_, err = client.LoginNonInteractive(context.Background(), login)
+		return err != nil
+	}, time.Second, 10*time.Millisecond, "new logins should be refused while draining")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.ErrorIs(t, fromStatusError(err), ErrShuttingDown)
+
+	_, err = client.GetPublicKeys(context.Background(), &interactor.GetPublicKeysRequest{})
+	require.NoError(t, err, "calls that do not start a login should still be served")
+	_, err = client.VerifyAuthentication(context.Background(), &interactor.AuthenticationAnswerRequest{AuthId: "auth-id"})
+	require.NoError(t, err, "the answer to a pending challenge should still be served")
+	select {
+	case err = <-served:
+		t.Fatalf("Serve returned %v before the pending login finished", err)
+	default:
+	}
+
+	pending.count.Store(0)
+	require.NoError(t, <-served)
+}
+
+func TestServe_AbandonsPendingChallenges(t *testing.T) {
+	t.Parallel()
+	const gracePeriod = 200 * time.Millisecond
+	pending := new(pendingChallenges)
+	pending.count.Store(1)
+	_, stop, served := drainingServer(t, gracePeriod, pending)
+
+	start := time.Now()
+	stop()
+	require.NoError(t, <-served, "abandoned challenges should not make the shutdown unclean")
+	elapsed := time.Since(start)
+	require.GreaterOrEqual(t, elapsed, gracePeriod/drainShare, "pending challenges should be waited for")
+	require.Less(t, elapsed, gracePeriod, "the calls in flight should keep the rest of the grace period")
+}
+
+func TestDrain_UnaryInterceptor(t *testing.T) {
+	t.Parallel()
+	drain := NewDrain(&config.Config{}, new(pendingChallenges))
+	drain.draining.Store(true)
+	handler := func(context.Context, any) (any, error) {
+		return "handled", nil
+	}
+
+	tests := []struct {
+		method  string
+		wantErr error
+	}{
+		{method: interactor.Auth_VerifyAuthentication_FullMethodName},
+		{method: interactor.Auth_GetSalt_FullMethodName},
+		{method: interactor.Auth_ValidateSession_FullMethodName},
+		{method: interactor.Auth_RefreshSession_FullMethodName},
+		{method: interactor.Auth_Logout_FullMethodName},
+		{method: interactor.Auth_GetPublicKeys_FullMethodName},
+		{method: "/grpc.health.v1.Health/Check"},
+		{method: interactor.Auth_CreateAuthenticationChallenge_FullMethodName, wantErr: ErrShuttingDown},
+		{method: interactor.Auth_LoginNonInteractive_FullMethodName, wantErr: ErrShuttingDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			t.Parallel()
+			res, err := drain.UnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Equal(t, codes.Unavailable, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "handled", res)
+		})
+	}
+}
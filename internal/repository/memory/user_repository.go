@@ -1,22 +1,26 @@
 package memory
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"log/slog"
+	"slices"
 	"sync"
+	"time"
 
 	authDomain "practical-case-test/internal/domain/auth"
-
-	"github.com/google/uuid"
+	"practical-case-test/internal/repository"
 )
 
 var (
 	ErrCastUser          = errors.New("error casting user")
 	ErrCastChallenge     = errors.New("error casting challenge")
 	ErrCastSession       = errors.New("error casting session")
-	ErrUserIDNotFound    = errors.New("userID not found")
+	ErrUserIDNotFound    = repository.ErrUserNotFound
 	ErrAuthIDNotFound    = errors.New("AuthID not found")
-	ErrSessionNotFound   = errors.New("session not found")
+	ErrSessionNotFound   = repository.ErrSessionNotFound
+	ErrProofReplayed     = repository.ErrProofReplayed
 	ErrUserAlreadyExists = errors.New("user already exists")
 )
 
@@ -24,6 +28,7 @@ type InMemAuthRepository struct {
 	userRegistration sync.Map
 	authChallenge    sync.Map
 	sessions         sync.Map
+	usedProofs       sync.Map
 }
 
 func NewInMemAuthRepository() *InMemAuthRepository {
@@ -36,8 +41,9 @@ func NewInMemAuthRepository() *InMemAuthRepository {
 // StoreUserRegistration stores the user registration in the InMemAuthRepository.
 // It first checks if the context has an error and returns the error if it exists.
 // Then it validates the user object and returns authDomain.ErrInvalidUser if it is invalid.
-// Next, it checks if the user already exists in the repository and returns ErrUserAlreadyExists if it does.
-// Finally, it stores the user registration in the repository using the user's UserID as the key.
+// Finally, it stores the user registration in the repository using the user's UserID as the key, unless a user
+// with that ID is already stored, in which case it returns ErrUserAlreadyExists and keeps the stored user. The
+// check and the store are one atomic step, so of two concurrent registrations of the same user only one succeeds.
 // It returns nil if the user registration is successfully stored.
 func (repo *InMemAuthRepository) StoreUserRegistration(ctx context.Context, user authDomain.User) error {
 	if err := ctx.Err(); err != nil {
@@ -46,10 +52,9 @@ func (repo *InMemAuthRepository) StoreUserRegistration(ctx context.Context, user
 	if !user.IsValid() {
 		return authDomain.ErrInvalidUser
 	}
-	if _, loaded := repo.userRegistration.Load(user.UserID()); loaded {
+	if _, loaded := repo.userRegistration.LoadOrStore(user.UserID(), user); loaded {
 		return ErrUserAlreadyExists
 	}
-	repo.userRegistration.Store(user.UserID(), user)
 	return nil
 }
 
@@ -106,11 +111,84 @@ func (repo *InMemAuthRepository) GetAuthenticationChallenge(ctx context.Context,
 	return &challenge, nil
 }
 
+// ConsumeAuthenticationChallenge atomically removes the authentication challenge with the provided authID
+// from the repository and returns it, so that every challenge can be answered at most once: of several
+// concurrent calls for the same authID only one gets the challenge, the others get ErrAuthIDNotFound.
+// It returns ErrCastChallenge if the stored value is not an authDomain.Challenge.
+func (repo *InMemAuthRepository) ConsumeAuthenticationChallenge(ctx context.Context, authID string) (*authDomain.Challenge, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	val, loadOk := repo.authChallenge.LoadAndDelete(authID)
+	if !loadOk {
+		return nil, ErrAuthIDNotFound
+	}
+	challenge, castOk := val.(authDomain.Challenge)
+	if !castOk {
+		return nil, ErrCastChallenge
+	}
+	return &challenge, nil
+}
+
+// CountChallenges returns how many authentication challenges in the repository were issued at or after the
+// Unix time issuedSince.
+func (repo *InMemAuthRepository) CountChallenges(ctx context.Context, issuedSince int64) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	count := 0
+	repo.authChallenge.Range(func(_, val any) bool {
+		if challenge, castOk := val.(authDomain.Challenge); castOk && challenge.Timestamp() >= issuedSince {
+			count++
+		}
+		return true
+	})
+	return count, nil
+}
+
+// PurgeExpiredChallenges deletes every authentication challenge created more than ttl before now, together
+// with any value that is not a challenge, and returns how many entries it deleted.
+func (repo *InMemAuthRepository) PurgeExpiredChallenges(now time.Time, ttl time.Duration) int {
+	purged := 0
+	repo.authChallenge.Range(func(key, val any) bool {
+		challenge, castOk := val.(authDomain.Challenge)
+		if castOk && now.Sub(time.Unix(challenge.Timestamp(), 0)) <= ttl {
+			return true
+		}
+		if _, deleted := repo.authChallenge.LoadAndDelete(key); deleted {
+			purged++
+		}
+		return true
+	})
+	return purged
+}
+
+// StartChallengeReaper starts a goroutine that calls PurgeExpiredChallenges with the given ttl every
+// interval, so that challenges that are never answered do not pile up. The goroutine stops when ctx is done.
+// It panics if interval is not positive.
+func (repo *InMemAuthRepository) StartChallengeReaper(ctx context.Context, ttl, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if purged := repo.PurgeExpiredChallenges(now, ttl); purged > 0 {
+					slog.Info("expired challenges purged", "count", purged)
+				}
+			}
+		}
+	}()
+}
+
 // StoreSession stores the given session in the in-memory repository.
 // It first checks if the context has an error, and returns the error if present.
 // Then it checks if the session is valid using the IsValid method of the session.
 // If the session is not valid, it returns the error ErrInvalidSession from the authDomain package.
-// It then generates a session key using the generateSessionKey function, passing the user ID and session ID.
+// It then generates a session key using the generateSessionKey function, passing the user ID and the session
+// ID, which is the hash of the session token, so that the token itself is never stored.
 // If an error occurs during the generation of the session key, it is returned.
 // Finally, it stores the session in the sessions map of the repository using the generated session key.
 // It returns nil if the session is stored successfully.
@@ -129,19 +207,19 @@ func (repo *InMemAuthRepository) StoreSession(ctx context.Context, session authD
 	return nil
 }
 
-// GetSession retrieves a session from the InMemAuthRepository based on the provided userID and sessionID.
+// GetSession retrieves a session from the InMemAuthRepository based on the provided userID and sessionHash.
 // It returns the session if found and valid, otherwise returns an error.
-// The session key is generated using the userID and sessionID strings.
+// The session key is generated using the userID and the hexadecimal sessionHash.
 // The session key is used to load the session from the sessions sync.Map.
 // If the session is not found, ErrSessionNotFound is returned.
-// If the loaded value is not of type *authDomain.Session, ErrCastSession is returned.
+// If the loaded value is not of type authDomain.Session, as stored by StoreSession, ErrCastSession is returned.
 // If the loaded session is not valid, authDomain.ErrInvalidSession is returned.
 // Finally, the method returns the session if it is found and valid, or an error otherwise.
-func (repo *InMemAuthRepository) GetSession(ctx context.Context, userID string, sessionID uuid.UUID) (*authDomain.Session, error) {
+func (repo *InMemAuthRepository) GetSession(ctx context.Context, userID string, sessionHash authDomain.SessionHash) (*authDomain.Session, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
-	sessionKey, err := generateSessionKey(userID, sessionID.String())
+	sessionKey, err := generateSessionKey(userID, sessionHash.String())
 	if err != nil {
 		return nil, err
 	}
@@ -149,14 +227,199 @@ func (repo *InMemAuthRepository) GetSession(ctx context.Context, userID string,
 	if !loadOk {
 		return nil, ErrSessionNotFound
 	}
-	session, castOk := val.(*authDomain.Session)
+	session, castOk := val.(authDomain.Session)
 	if !castOk {
 		return nil, ErrCastSession
 	}
 	if !session.IsValid() {
 		return nil, authDomain.ErrInvalidSession
 	}
-	return session, nil
+	return &session, nil
+}
+
+// RefreshSession records lastSeen as the time the session of the user with the provided sessionHash was last
+// seen at and returns the updated session. The update is atomic: if the session is deleted concurrently,
+// RefreshSession fails instead of bringing it back.
+// It returns ErrSessionNotFound if there is no such session, or ErrCastSession if the stored value is not
+// an authDomain.Session.
+func (repo *InMemAuthRepository) RefreshSession(ctx context.Context, userID string, sessionHash authDomain.SessionHash,
+	lastSeen int64) (*authDomain.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sessionKey, err := generateSessionKey(userID, sessionHash.String())
+	if err != nil {
+		return nil, err
+	}
+	for {
+		val, loadOk := repo.sessions.Load(sessionKey)
+		if !loadOk {
+			return nil, ErrSessionNotFound
+		}
+		session, castOk := val.(authDomain.Session)
+		if !castOk {
+			return nil, ErrCastSession
+		}
+		refreshed := session.Refreshed(lastSeen)
+		if repo.sessions.CompareAndSwap(sessionKey, val, refreshed) {
+			return &refreshed, nil
+		}
+	}
+}
+
+// DeleteSession removes the session of the user with the provided sessionHash from the repository, so that
+// it can no longer be found. It returns ErrSessionNotFound if there is no such session.
+func (repo *InMemAuthRepository) DeleteSession(ctx context.Context, userID string, sessionHash authDomain.SessionHash) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	sessionKey, err := generateSessionKey(userID, sessionHash.String())
+	if err != nil {
+		return err
+	}
+	if _, deleted := repo.sessions.LoadAndDelete(sessionKey); !deleted {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// ListSessions returns every session stored for the user, including expired ones that have not been purged
+// yet, ordered by login time. It returns an empty list for a user without sessions.
+func (repo *InMemAuthRepository) ListSessions(ctx context.Context, userID string) ([]authDomain.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sessions := make([]authDomain.Session, 0)
+	repo.sessions.Range(func(_, val any) bool {
+		if session, castOk := val.(authDomain.Session); castOk && session.UserID() == userID {
+			sessions = append(sessions, session)
+		}
+		return true
+	})
+	slices.SortFunc(sessions, func(a, b authDomain.Session) int {
+		return cmp.Compare(a.LoginTimestamp(), b.LoginTimestamp())
+	})
+	return sessions, nil
+}
+
+// RevokeAllSessions deletes every session of the user except the one with the ID keep, which may be
+// the zero hash to delete them all, and returns how many sessions it deleted.
+func (repo *InMemAuthRepository) RevokeAllSessions(ctx context.Context, userID string, keep authDomain.SessionHash) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	revoked := 0
+	repo.sessions.Range(func(key, val any) bool {
+		session, castOk := val.(authDomain.Session)
+		if !castOk || session.UserID() != userID || session.ID() == keep {
+			return true
+		}
+		if _, deleted := repo.sessions.LoadAndDelete(key); deleted {
+			revoked++
+		}
+		return true
+	})
+	return revoked, nil
+}
+
+// PurgeExpiredSessions deletes every session that has expired at now under the given absolute and idle TTLs,
+// see authDomain.Session.ExpiresAt, together with any value that is not a session, and returns how many
+// entries it deleted.
+func (repo *InMemAuthRepository) PurgeExpiredSessions(now time.Time, absoluteTTL, idleTTL time.Duration) int {
+	purged := 0
+	repo.sessions.Range(func(key, val any) bool {
+		session, castOk := val.(authDomain.Session)
+		if castOk && !session.IsExpired(now, absoluteTTL, idleTTL) {
+			return true
+		}
+		// Only delete the value that was checked, so that a session refreshed meanwhile survives.
+		if repo.sessions.CompareAndDelete(key, val) {
+			purged++
+		}
+		return true
+	})
+	return purged
+}
+
+// StartSessionReaper starts a goroutine that calls PurgeExpiredSessions with the given TTLs every interval, so
+// that sessions nobody logs out of do not pile up. The goroutine stops when ctx is done.
+// It panics if interval is not positive.
+func (repo *InMemAuthRepository) StartSessionReaper(ctx context.Context, absoluteTTL, idleTTL, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if purged := repo.PurgeExpiredSessions(now, absoluteTTL, idleTTL); purged > 0 {
+					slog.Info("expired sessions purged", "count", purged)
+				}
+			}
+		}
+	}()
+}
+
+// RecordProof stores the ID of a non-interactive proof together with its timestamp, and returns
+// ErrProofReplayed if the ID is stored already. Of several concurrent calls for the same ID only one succeeds.
+func (repo *InMemAuthRepository) RecordProof(ctx context.Context, proofID string, timestamp int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if _, loaded := repo.usedProofs.LoadOrStore(proofID, timestamp); loaded {
+		return ErrProofReplayed
+	}
+	return nil
+}
+
+// PurgeExpiredProofs deletes the ID of every recorded proof whose timestamp lies more than maxSkew before now,
+// which the verifier no longer accepts anyway, together with any value that is not a timestamp, and returns
+// how many entries it deleted.
+func (repo *InMemAuthRepository) PurgeExpiredProofs(now time.Time, maxSkew time.Duration) int {
+	purged := 0
+	repo.usedProofs.Range(func(key, val any) bool {
+		timestamp, castOk := val.(int64)
+		if castOk && now.Sub(time.Unix(timestamp, 0)) <= maxSkew {
+			return true
+		}
+		if _, deleted := repo.usedProofs.LoadAndDelete(key); deleted {
+			purged++
+		}
+		return true
+	})
+	return purged
+}
+
+// StartProofReaper starts a goroutine that calls PurgeExpiredProofs with the given maximum skew every
+// interval, so that the IDs of used proofs do not pile up. The goroutine stops when ctx is done.
+// It panics if interval is not positive.
+func (repo *InMemAuthRepository) StartProofReaper(ctx context.Context, maxSkew, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if purged := repo.PurgeExpiredProofs(now, maxSkew); purged > 0 {
+					slog.Info("expired proofs purged", "count", purged)
+				}
+			}
+		}
+	}()
+}
+
+// Flush does nothing but report whether ctx is done: the in-memory repository applies every write before the
+// call that made it returns, so none is ever pending. Nothing survives the process either.
+func (repo *InMemAuthRepository) Flush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Ping only reports whether ctx is done: the in-memory repository is always available.
+func (repo *InMemAuthRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
 }
 
 // generateSessionKey takes a userID and sessionID as input and generates a session key
@@ -1,18 +1,35 @@
 package memory
 
 import (
+	"cmp"
 	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
 	"math/big"
+	"slices"
 	"sync"
 	"testing"
 	"time"
 
 	authDomain "practical-case-test/internal/domain/auth"
 
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
+var testSalt = []byte("0123456789abcdef")
+
+var testKDF = authDomain.KDFParams{Time: 1, MemoryKiB: 64, Threads: 1}
+
+// newSessionHash returns a random session hash.
+func newSessionHash(t *testing.T) authDomain.SessionHash {
+	t.Helper()
+	var h authDomain.SessionHash
+	_, err := rand.Read(h[:])
+	require.NoError(t, err)
+	return h
+}
+
 func TestInMemAuthRepository_GetAuthenticationChallenge(t *testing.T) {
 	repo := &InMemAuthRepository{
 		userRegistration: sync.Map{},
@@ -20,7 +37,7 @@ func TestInMemAuthRepository_GetAuthenticationChallenge(t *testing.T) {
 		sessions:         sync.Map{},
 	}
 
-	challenge, err := authDomain.NewChallenge(big.NewInt(2), "user-id-1", 0, 2, time.Now().Unix())
+	challenge, err := authDomain.NewChallenge(big.NewInt(2), "user-id-1", big.NewInt(0), big.NewInt(2), time.Now().Unix())
 	require.NoError(t, err)
 
 	err = repo.StoreAuthenticationChallenge(context.Background(), *challenge)
@@ -69,6 +86,159 @@ func TestInMemAuthRepository_GetAuthenticationChallenge(t *testing.T) {
 	}
 }
 
+func TestInMemAuthRepository_ConsumeAuthenticationChallenge(t *testing.T) {
+	repo := NewInMemAuthRepository()
+
+	challenge, err := authDomain.NewChallenge(big.NewInt(2), "user-id-1", big.NewInt(0), big.NewInt(2), time.Now().Unix())
+	require.NoError(t, err)
+	require.NoError(t, repo.StoreAuthenticationChallenge(context.Background(), *challenge))
+	authID := challenge.AuthID().String()
+
+	// Of many concurrent attempts to answer the same challenge exactly one may get it.
+	const attempts = 16
+	var wg sync.WaitGroup
+	results := make([]error, attempts)
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, results[i] = repo.ConsumeAuthenticationChallenge(context.Background(), authID)
+		}()
+	}
+	wg.Wait()
+	consumed := 0
+	for _, err := range results {
+		if err == nil {
+			consumed++
+			continue
+		}
+		require.ErrorIs(t, err, ErrAuthIDNotFound)
+	}
+	require.Equal(t, 1, consumed, "a challenge should be consumed exactly once")
+
+	_, err = repo.GetAuthenticationChallenge(context.Background(), authID)
+	require.ErrorIs(t, err, ErrAuthIDNotFound, "a consumed challenge should be gone")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = repo.ConsumeAuthenticationChallenge(ctx, authID)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInMemAuthRepository_CountChallenges(t *testing.T) {
+	t.Parallel()
+	repo := NewInMemAuthRepository()
+	now := time.Now()
+
+	for i, issued := range []time.Time{now, now.Add(-30 * time.Second), now.Add(-2 * time.Minute)} {
+		challenge, err := authDomain.NewChallenge(big.NewInt(2), fmt.Sprintf("user-id-%d", i), big.NewInt(1), big.NewInt(2), issued.Unix())
+		require.NoError(t, err)
+		require.NoError(t, repo.StoreAuthenticationChallenge(context.Background(), *challenge))
+	}
+	repo.authChallenge.Store("corrupted", "not a challenge")
+
+	count, err := repo.CountChallenges(context.Background(), now.Add(-time.Minute).Unix())
+	require.NoError(t, err)
+	require.Equal(t, 2, count, "only the challenges issued since the given time should count")
+	count, err = repo.CountChallenges(context.Background(), now.Add(time.Minute).Unix())
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = repo.CountChallenges(ctx, 0)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInMemAuthRepository_PurgeExpiredChallenges(t *testing.T) {
+	repo := NewInMemAuthRepository()
+	now := time.Now()
+
+	fresh, err := authDomain.NewChallenge(big.NewInt(2), "user-id-1", big.NewInt(1), big.NewInt(2), now.Unix())
+	require.NoError(t, err)
+	stale, err := authDomain.NewChallenge(big.NewInt(2), "user-id-2", big.NewInt(1), big.NewInt(2), now.Add(-2*time.Minute).Unix())
+	require.NoError(t, err)
+	require.NoError(t, repo.StoreAuthenticationChallenge(context.Background(), *fresh))
+	require.NoError(t, repo.StoreAuthenticationChallenge(context.Background(), *stale))
+	repo.authChallenge.Store("corrupted", "not a challenge")
+
+	require.Equal(t, 2, repo.PurgeExpiredChallenges(now, time.Minute))
+
+	_, err = repo.GetAuthenticationChallenge(context.Background(), fresh.AuthID().String())
+	require.NoError(t, err, "a fresh challenge should be kept")
+	_, err = repo.GetAuthenticationChallenge(context.Background(), stale.AuthID().String())
+	require.ErrorIs(t, err, ErrAuthIDNotFound, "an expired challenge should be purged")
+	_, loaded := repo.authChallenge.Load("corrupted")
+	require.False(t, loaded, "a value that is not a challenge should be purged")
+
+	require.Zero(t, repo.PurgeExpiredChallenges(now, time.Minute))
+}
+
+func TestInMemAuthRepository_StartChallengeReaper(t *testing.T) {
+	repo := NewInMemAuthRepository()
+	stale, err := authDomain.NewChallenge(big.NewInt(2), "user-id-1", big.NewInt(1), big.NewInt(2), time.Now().Add(-time.Hour).Unix())
+	require.NoError(t, err)
+	require.NoError(t, repo.StoreAuthenticationChallenge(context.Background(), *stale))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo.StartChallengeReaper(ctx, time.Minute, time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		_, err := repo.GetAuthenticationChallenge(context.Background(), stale.AuthID().String())
+		return errors.Is(err, ErrAuthIDNotFound)
+	}, time.Second, time.Millisecond, "the reaper should purge expired challenges")
+}
+
+func TestInMemAuthRepository_RecordProof(t *testing.T) {
+	t.Parallel()
+	repo := NewInMemAuthRepository()
+	now := time.Now().Unix()
+
+	require.NoError(t, repo.RecordProof(context.Background(), "proof-1", now))
+	require.ErrorIs(t, repo.RecordProof(context.Background(), "proof-1", now), ErrProofReplayed)
+	require.NoError(t, repo.RecordProof(context.Background(), "proof-2", now))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, repo.RecordProof(ctx, "proof-3", now), context.Canceled)
+}
+
+func TestInMemAuthRepository_PurgeExpiredProofs(t *testing.T) {
+	t.Parallel()
+	repo := NewInMemAuthRepository()
+	now := time.Now()
+
+	require.NoError(t, repo.RecordProof(context.Background(), "fresh", now.Add(-20*time.Second).Unix()))
+	require.NoError(t, repo.RecordProof(context.Background(), "future", now.Add(20*time.Second).Unix()))
+	require.NoError(t, repo.RecordProof(context.Background(), "stale", now.Add(-time.Minute).Unix()))
+	repo.usedProofs.Store("corrupted", "not a timestamp")
+
+	require.Equal(t, 2, repo.PurgeExpiredProofs(now, 30*time.Second))
+
+	require.ErrorIs(t, repo.RecordProof(context.Background(), "fresh", now.Unix()), ErrProofReplayed,
+		"a proof that is still accepted should be kept")
+	require.ErrorIs(t, repo.RecordProof(context.Background(), "future", now.Unix()), ErrProofReplayed)
+	require.NoError(t, repo.RecordProof(context.Background(), "stale", now.Unix()), "an expired proof should be purged")
+	_, loaded := repo.usedProofs.Load("corrupted")
+	require.False(t, loaded, "a value that is not a timestamp should be purged")
+}
+
+func TestInMemAuthRepository_StartProofReaper(t *testing.T) {
+	t.Parallel()
+	repo := NewInMemAuthRepository()
+	require.NoError(t, repo.RecordProof(context.Background(), "stale", time.Now().Add(-time.Hour).Unix()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo.StartProofReaper(ctx, time.Minute, time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		_, loaded := repo.usedProofs.Load("stale")
+		return !loaded
+	}, time.Second, time.Millisecond, "the reaper should purge expired proofs")
+}
+
 func TestInMemAuthRepository_GetSession(t *testing.T) {
 	repo := &InMemAuthRepository{
 		userRegistration: sync.Map{},
@@ -76,19 +246,16 @@ func TestInMemAuthRepository_GetSession(t *testing.T) {
 		sessions:         sync.Map{},
 	}
 
-	sessionID, _ := uuid.Parse("550e8400-e29b-41d4-a716-446655440000")
+	sessionID := newSessionHash(t)
 	testSession, err := authDomain.NewSession(sessionID, "existingUser", time.Now().Unix())
 	require.NoError(t, err)
 
-	sessionKey, err := generateSessionKey("existingUser", sessionID.String())
-	require.NoError(t, err)
-
-	repo.sessions.Store(sessionKey, testSession)
+	require.NoError(t, repo.StoreSession(context.Background(), *testSession))
 
 	type args struct {
 		ctx       context.Context
 		userID    string
-		sessionID uuid.UUID
+		sessionID authDomain.SessionHash
 	}
 	tests := []struct {
 		name    string
@@ -100,7 +267,7 @@ func TestInMemAuthRepository_GetSession(t *testing.T) {
 			args: args{
 				ctx:       context.Background(),
 				userID:    "nonexistentUser",
-				sessionID: uuid.New(), // non existing uuid
+				sessionID: newSessionHash(t), // non existing session
 			},
 			wantErr: true,
 		},
@@ -129,6 +296,183 @@ func TestInMemAuthRepository_GetSession(t *testing.T) {
 		})
 	}
 }
+func TestInMemAuthRepository_SessionRoundTrip(t *testing.T) {
+	repo := NewInMemAuthRepository()
+
+	sessions := make([]*authDomain.Session, 0, 3)
+	for i, userID := range []string{"user-id-1", "user-id-1", "user-id-2"} {
+		opened, err := authDomain.NewSession(newSessionHash(t), userID, time.Now().Unix())
+		require.NoError(t, err)
+		session := opened.WithClient(authDomain.NewClientInfo(fmt.Sprintf("192.0.2.%d:54321", i), "zkp-prover", "phone"))
+		require.NoError(t, repo.StoreSession(context.Background(), session))
+		sessions = append(sessions, &session)
+	}
+
+	for _, want := range sessions {
+		got, err := repo.GetSession(context.Background(), want.UserID(), want.ID())
+		require.NoError(t, err, "a stored session should be found")
+		require.Equal(t, want, got, "the session read back, with its client, should equal the stored one")
+	}
+
+	_, err := repo.GetSession(context.Background(), "user-id-2", sessions[0].ID())
+	require.ErrorIs(t, err, ErrSessionNotFound, "a session should not be found under another user")
+
+	_, err = repo.GetSession(context.Background(), "user-id-1", newSessionHash(t))
+	require.ErrorIs(t, err, ErrSessionNotFound)
+
+	corruptedID := newSessionHash(t)
+	key, err := generateSessionKey("user-id-1", corruptedID.String())
+	require.NoError(t, err)
+	repo.sessions.Store(key, "not a session")
+	_, err = repo.GetSession(context.Background(), "user-id-1", corruptedID)
+	require.ErrorIs(t, err, ErrCastSession)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = repo.GetSession(ctx, sessions[0].UserID(), sessions[0].ID())
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInMemAuthRepository_RefreshSession(t *testing.T) {
+	repo := NewInMemAuthRepository()
+	login := time.Now().Add(-time.Hour)
+	client := authDomain.NewClientInfo("192.0.2.1:54321", "zkp-prover", "phone")
+	opened, err := authDomain.NewSession(newSessionHash(t), "user-id-1", login.Unix())
+	require.NoError(t, err)
+	session := opened.WithClient(client)
+	require.NoError(t, repo.StoreSession(context.Background(), session))
+
+	now := time.Now().Unix()
+	refreshed, err := repo.RefreshSession(context.Background(), "user-id-1", session.ID(), now)
+	require.NoError(t, err)
+	require.Equal(t, now, refreshed.LastSeenTimestamp())
+	require.Equal(t, login.Unix(), refreshed.LoginTimestamp())
+	require.Equal(t, client, refreshed.Client(), "a refresh should keep the client of the session")
+
+	got, err := repo.GetSession(context.Background(), "user-id-1", session.ID())
+	require.NoError(t, err)
+	require.Equal(t, refreshed, got, "the refresh should be persisted")
+
+	_, err = repo.RefreshSession(context.Background(), "user-id-2", session.ID(), now)
+	require.ErrorIs(t, err, ErrSessionNotFound, "a session should not be refreshed under another user")
+}
+
+func TestInMemAuthRepository_DeleteSession(t *testing.T) {
+	repo := NewInMemAuthRepository()
+	session, err := authDomain.NewSession(newSessionHash(t), "user-id-1", time.Now().Unix())
+	require.NoError(t, err)
+	require.NoError(t, repo.StoreSession(context.Background(), *session))
+
+	require.ErrorIs(t, repo.DeleteSession(context.Background(), "user-id-2", session.ID()), ErrSessionNotFound,
+		"a session should not be deleted under another user")
+	require.NoError(t, repo.DeleteSession(context.Background(), "user-id-1", session.ID()))
+
+	_, err = repo.GetSession(context.Background(), "user-id-1", session.ID())
+	require.ErrorIs(t, err, ErrSessionNotFound, "a deleted session should be gone")
+	require.ErrorIs(t, repo.DeleteSession(context.Background(), "user-id-1", session.ID()), ErrSessionNotFound)
+	_, err = repo.RefreshSession(context.Background(), "user-id-1", session.ID(), time.Now().Unix())
+	require.ErrorIs(t, err, ErrSessionNotFound, "a deleted session should not be refreshed back")
+}
+
+func TestInMemAuthRepository_ListSessions(t *testing.T) {
+	repo := NewInMemAuthRepository()
+	now := time.Now()
+
+	var want []authDomain.Session
+	for i, userID := range []string{"user-id-1", "user-id-2", "user-id-1", "user-id-1"} {
+		// Store the sessions of user-id-1 out of login order.
+		session, err := authDomain.NewSession(newSessionHash(t), userID, now.Add(time.Duration(-i%3)*time.Hour).Unix())
+		require.NoError(t, err)
+		require.NoError(t, repo.StoreSession(context.Background(), *session))
+		if userID == "user-id-1" {
+			want = append(want, *session)
+		}
+	}
+	repo.sessions.Store("corrupted", "not a session")
+
+	got, err := repo.ListSessions(context.Background(), "user-id-1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, want, got)
+	require.True(t, slices.IsSortedFunc(got, func(a, b authDomain.Session) int {
+		return cmp.Compare(a.LoginTimestamp(), b.LoginTimestamp())
+	}), "sessions should be ordered by login time")
+
+	got, err = repo.ListSessions(context.Background(), "user-id-3")
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestInMemAuthRepository_RevokeAllSessions(t *testing.T) {
+	repo := NewInMemAuthRepository()
+
+	var ids []authDomain.SessionHash
+	for _, userID := range []string{"user-id-1", "user-id-1", "user-id-1", "user-id-2"} {
+		session, err := authDomain.NewSession(newSessionHash(t), userID, time.Now().Unix())
+		require.NoError(t, err)
+		require.NoError(t, repo.StoreSession(context.Background(), *session))
+		ids = append(ids, session.ID())
+	}
+
+	revoked, err := repo.RevokeAllSessions(context.Background(), "user-id-1", ids[0])
+	require.NoError(t, err)
+	require.Equal(t, 2, revoked, "every other session of the user should be revoked")
+
+	got, err := repo.ListSessions(context.Background(), "user-id-1")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, ids[0], got[0].ID(), "the kept session should survive")
+
+	revoked, err = repo.RevokeAllSessions(context.Background(), "user-id-1", authDomain.SessionHash{})
+	require.NoError(t, err)
+	require.Equal(t, 1, revoked)
+
+	_, err = repo.GetSession(context.Background(), "user-id-2", ids[3])
+	require.NoError(t, err, "sessions of other users should be left alone")
+}
+
+func TestInMemAuthRepository_PurgeExpiredSessions(t *testing.T) {
+	repo := NewInMemAuthRepository()
+	now := time.Now()
+
+	fresh, err := authDomain.NewSession(newSessionHash(t), "user-id-1", now.Add(-time.Minute).Unix())
+	require.NoError(t, err)
+	idle, err := authDomain.NewSession(newSessionHash(t), "user-id-1", now.Add(-time.Hour).Unix())
+	require.NoError(t, err)
+	old, err := authDomain.NewSession(newSessionHash(t), "user-id-2", now.Add(-48*time.Hour).Unix())
+	require.NoError(t, err)
+	for _, session := range []authDomain.Session{*fresh, *idle, old.Refreshed(now.Unix())} {
+		require.NoError(t, repo.StoreSession(context.Background(), session))
+	}
+	repo.sessions.Store("corrupted", "not a session")
+
+	require.Equal(t, 3, repo.PurgeExpiredSessions(now, 24*time.Hour, 30*time.Minute))
+
+	_, err = repo.GetSession(context.Background(), fresh.UserID(), fresh.ID())
+	require.NoError(t, err, "an active session should be kept")
+	_, err = repo.GetSession(context.Background(), idle.UserID(), idle.ID())
+	require.ErrorIs(t, err, ErrSessionNotFound, "an idle session should be purged")
+	_, err = repo.GetSession(context.Background(), old.UserID(), old.ID())
+	require.ErrorIs(t, err, ErrSessionNotFound, "a session past its absolute lifetime should be purged")
+	_, loaded := repo.sessions.Load("corrupted")
+	require.False(t, loaded, "a value that is not a session should be purged")
+}
+
+func TestInMemAuthRepository_StartSessionReaper(t *testing.T) {
+	repo := NewInMemAuthRepository()
+	stale, err := authDomain.NewSession(newSessionHash(t), "user-id-1", time.Now().Add(-time.Hour).Unix())
+	require.NoError(t, err)
+	require.NoError(t, repo.StoreSession(context.Background(), *stale))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo.StartSessionReaper(ctx, 24*time.Hour, time.Minute, time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		_, err := repo.GetSession(context.Background(), stale.UserID(), stale.ID())
+		return errors.Is(err, ErrSessionNotFound)
+	}, time.Second, time.Millisecond, "the reaper should purge expired sessions")
+}
+
 func TestInMemAuthRepository_GetUserRegistration(t *testing.T) {
 	repo := &InMemAuthRepository{
 		userRegistration: sync.Map{},
@@ -137,7 +481,7 @@ func TestInMemAuthRepository_GetUserRegistration(t *testing.T) {
 	}
 
 	userID := "existingUser"
-	testUser, _ := authDomain.NewUser(userID, 10, 20)
+	testUser, _ := authDomain.NewUser(userID, big.NewInt(10), big.NewInt(20), testSalt, testKDF)
 	// repo.userRegistration.Store(userID, testUser)
 
 	err := repo.StoreUserRegistration(context.Background(), *testUser)
@@ -191,10 +535,10 @@ func TestInMemAuthRepository_StoreAuthenticationChallenge(t *testing.T) {
 		sessions:         sync.Map{},
 	}
 
-	challenge1, err := authDomain.NewChallenge(big.NewInt(2), "user-id-1", 0, 1, time.Now().Unix())
+	challenge1, err := authDomain.NewChallenge(big.NewInt(2), "user-id-1", big.NewInt(0), big.NewInt(1), time.Now().Unix())
 	require.NoError(t, err)
 
-	challenge2, err := authDomain.NewChallenge(big.NewInt(3), "user-id-2", 1, 5, time.Now().Unix())
+	challenge2, err := authDomain.NewChallenge(big.NewInt(3), "user-id-2", big.NewInt(1), big.NewInt(5), time.Now().Unix())
 	require.NoError(t, err)
 
 	type args struct {
@@ -259,10 +603,10 @@ func TestInMemAuthRepository_StoreSession(t *testing.T) {
 		sessions:         sync.Map{},
 	}
 
-	session1, err := authDomain.NewSession(uuid.New(), "user-id-1", time.Now().Add(5*time.Hour).Unix())
+	session1, err := authDomain.NewSession(newSessionHash(t), "user-id-1", time.Now().Add(5*time.Hour).Unix())
 	require.NoError(t, err)
 
-	session2, err := authDomain.NewSession(uuid.New(), "user-id-2", time.Now().Add(2*time.Hour).Unix())
+	session2, err := authDomain.NewSession(newSessionHash(t), "user-id-2", time.Now().Add(2*time.Hour).Unix())
 	require.NoError(t, err)
 
 	type args struct {
@@ -329,10 +673,10 @@ func TestInMemAuthRepository_StoreUserRegistration(t *testing.T) {
 		sessions:         sync.Map{},
 	}
 
-	user1, err := authDomain.NewUser("user-id-1", 1, 2)
+	user1, err := authDomain.NewUser("user-id-1", big.NewInt(1), big.NewInt(2), testSalt, testKDF)
 	require.NoError(t, err)
 
-	user2, err := authDomain.NewUser("user-id-2", 3, 4)
+	user2, err := authDomain.NewUser("user-id-2", big.NewInt(3), big.NewInt(4), testSalt, testKDF)
 	require.NoError(t, err)
 
 	type args struct {
@@ -391,6 +735,37 @@ func TestInMemAuthRepository_StoreUserRegistration(t *testing.T) {
 	}
 }
 
+func TestInMemAuthRepository_StoreUserRegistrationConcurrently(t *testing.T) {
+	repo := NewInMemAuthRepository()
+
+	const registrations = 16
+	var (
+		wg     sync.WaitGroup
+		stored = make(chan *authDomain.User, registrations)
+	)
+	for i := range registrations {
+		user, err := authDomain.NewUser("user-id-1", big.NewInt(int64(i+1)), big.NewInt(2), testSalt, testKDF)
+		require.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.StoreUserRegistration(context.Background(), *user)
+			if err == nil {
+				stored <- user
+				return
+			}
+			require.ErrorIs(t, err, ErrUserAlreadyExists)
+		}()
+	}
+	wg.Wait()
+	close(stored)
+
+	require.Len(t, stored, 1, "exactly one of the concurrent registrations should succeed")
+	got, err := repo.GetUserRegistration(context.Background(), "user-id-1")
+	require.NoError(t, err)
+	require.Equal(t, <-stored, got, "the successful registration should not be overwritten")
+}
+
 func TestNewInMemAuthRepository(t *testing.T) {
 	tests := []struct {
 		name string
@@ -430,6 +805,26 @@ func TestNewInMemAuthRepository(t *testing.T) {
 	}
 }
 
+func TestInMemAuthRepository_Flush(t *testing.T) {
+	t.Parallel()
+	repo := NewInMemAuthRepository()
+	require.NoError(t, repo.Flush(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, repo.Flush(ctx), context.Canceled)
+}
+
+func TestInMemAuthRepository_Ping(t *testing.T) {
+	t.Parallel()
+	repo := NewInMemAuthRepository()
+	require.NoError(t, repo.Ping(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, repo.Ping(ctx), context.Canceled)
+}
+
 func Test_generateSessionKey(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -2,17 +2,47 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	authDomain "practical-case-test/internal/domain/auth"
-
-	"github.com/google/uuid"
 )
 
+// ErrUserNotFound is returned by AuthRepository.GetUserRegistration when no user is registered under the
+// given name.
+var ErrUserNotFound = errors.New("userID not found")
+
+// ErrSessionNotFound is returned by the session methods of AuthRepository when the user has no session with
+// the given ID. Sessions are identified by the hash of their token, see authDomain.HashSessionToken, so that
+// a repository never holds tokens that could be used to impersonate a user.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrProofReplayed is returned by AuthRepository.RecordProof when a non-interactive proof with the same ID was
+// already recorded, that is when a proof is submitted a second time.
+var ErrProofReplayed = errors.New("proof was already used")
+
 type AuthRepository interface {
 	StoreUserRegistration(ctx context.Context, userID authDomain.User) error
 	GetUserRegistration(ctx context.Context, userID string) (*authDomain.User, error)
 	StoreAuthenticationChallenge(ctx context.Context, challenge authDomain.Challenge) error
 	GetAuthenticationChallenge(ctx context.Context, authID string) (*authDomain.Challenge, error)
+	ConsumeAuthenticationChallenge(ctx context.Context, authID string) (*authDomain.Challenge, error)
+	// CountChallenges returns how many stored challenges were issued at or after the Unix time issuedSince, so
+	// that the verifier can wait for the answers to the challenges that have not expired before it stops.
+	CountChallenges(ctx context.Context, issuedSince int64) (int, error)
 	StoreSession(ctx context.Context, session authDomain.Session) error
-	GetSession(ctx context.Context, userID string, sessionID uuid.UUID) (*authDomain.Session, error)
+	GetSession(ctx context.Context, userID string, sessionHash authDomain.SessionHash) (*authDomain.Session, error)
+	RefreshSession(ctx context.Context, userID string, sessionHash authDomain.SessionHash, lastSeen int64) (*authDomain.Session, error)
+	DeleteSession(ctx context.Context, userID string, sessionHash authDomain.SessionHash) error
+	ListSessions(ctx context.Context, userID string) ([]authDomain.Session, error)
+	RevokeAllSessions(ctx context.Context, userID string, keep authDomain.SessionHash) (int, error)
+	// RecordProof records that the non-interactive proof with the given ID and timestamp was used to log in,
+	// and returns ErrProofReplayed if it already was. It must keep the ID for as long as the timestamp is
+	// accepted, so that a captured proof cannot be replayed.
+	RecordProof(ctx context.Context, proofID string, timestamp int64) error
+	// Flush returns once every write accepted so far is durable in the underlying store, or with the error
+	// of ctx if it is done first. The verifier calls it before it exits.
+	Flush(ctx context.Context) error
+	// Ping checks that the underlying store can be reached, so that health checks can report whether the
+	// verifier can serve logins.
+	Ping(ctx context.Context) error
 }
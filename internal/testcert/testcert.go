@@ -0,0 +1,126 @@
+// Package testcert generates throwaway certificates for tests of TLS connections between the prover and the
+// verifier.
+package testcert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// validity is how long the generated certificates are valid, starting an hour in the past to allow for
+// clock skew.
+const validity = 24 * time.Hour
+
+// ClientName is the common name of the client certificate written by Write.
+const ClientName = "zkp-prover"
+
+// serialBits is the bit length of the random serial numbers of the certificates.
+const serialBits = 128
+
+// Files are the paths of the PEM files written by Write.
+type Files struct {
+	CAFile         string
+	ServerCertFile string
+	ServerKeyFile  string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// Write generates a CA together with a server certificate for localhost and 127.0.0.1 and a client
+// certificate issued by it, writes them as PEM files into dir and returns their paths.
+func Write(dir string) (*Files, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate := template("zkp test CA")
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	files := &Files{
+		CAFile:         filepath.Join(dir, "ca.pem"),
+		ServerCertFile: filepath.Join(dir, "server.pem"),
+		ServerKeyFile:  filepath.Join(dir, "server-key.pem"),
+		ClientCertFile: filepath.Join(dir, "client.pem"),
+		ClientKeyFile:  filepath.Join(dir, "client-key.pem"),
+	}
+	if err = writePEM(files.CAFile, "CERTIFICATE", caDER); err != nil {
+		return nil, err
+	}
+
+	server := template("localhost")
+	server.DNSNames = []string{"localhost"}
+	server.IPAddresses = []net.IP{net.ParseIP("127.0.0.1"), net.IPv6loopback}
+	server.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	if err = issue(server, ca, caKey, files.ServerCertFile, files.ServerKeyFile); err != nil {
+		return nil, err
+	}
+
+	client := template(ClientName)
+	client.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if err = issue(client, ca, caKey, files.ClientCertFile, files.ClientKeyFile); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// template returns a certificate template with the given common name and a random serial number.
+func template(commonName string) *x509.Certificate {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits))
+	if err != nil {
+		serial = big.NewInt(time.Now().UnixNano())
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+}
+
+// issue signs a certificate for a fresh key from tmpl with the CA and writes both to the given files.
+func issue(tmpl, ca *x509.Certificate, caKey crypto.Signer, certFile, keyFile string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, key.Public(), caKey)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err = writePEM(certFile, "CERTIFICATE", der); err != nil {
+		return err
+	}
+	return writePEM(keyFile, "PRIVATE KEY", keyDER)
+}
+
+// writePEM writes a single PEM block of the given type to a file only the owner can read.
+func writePEM(path, blockType string, der []byte) error {
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+}
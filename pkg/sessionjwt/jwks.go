@@ -0,0 +1,90 @@
+package sessionjwt
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// keyTypeOKP and curveEd25519 are the key type and the curve of an Ed25519 key in a JWK, see RFC 8037.
+// keyUseSignature marks a key as meant for verifying signatures.
+const (
+	keyTypeOKP      = "OKP"
+	curveEd25519    = "Ed25519"
+	keyUseSignature = "sig"
+)
+
+// JWK is a public key in JSON Web Key form (RFC 7517). Only Ed25519 keys are used by this package: their
+// key type is "OKP", their curve "Ed25519" and X holds the base64url-encoded public key (RFC 8037).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+}
+
+// KeySet is a JSON Web Key Set, the form in which the verifier publishes the keys signed tokens are
+// verified with. Marshaling it with encoding/json yields the JWKS document.
+type KeySet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewKeySet returns a KeySet holding the given Ed25519 public keys, each identified by its KeyID.
+// It returns ErrInvalidKey if one of them does not have ed25519.PublicKeySize bytes.
+func NewKeySet(keys ...ed25519.PublicKey) (*KeySet, error) {
+	set := &KeySet{Keys: make([]JWK, 0, len(keys))}
+	for _, key := range keys {
+		if len(key) != ed25519.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+		set.Keys = append(set.Keys, JWK{
+			KeyType:   keyTypeOKP,
+			Curve:     curveEd25519,
+			X:         base64.RawURLEncoding.EncodeToString(key),
+			KeyID:     KeyID(key),
+			Algorithm: Algorithm,
+			Use:       keyUseSignature,
+		})
+	}
+	return set, nil
+}
+
+// ParseKeySet parses a JWKS document as returned by the GetPublicKeys call of the verifier.
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var set KeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid key set: %w", err)
+	}
+	return &set, nil
+}
+
+// Key returns the Ed25519 public key with the given key ID. Keys of another type, for another algorithm
+// or meant for another use are skipped. It returns ErrUnknownKey if the set holds no such key, or
+// ErrInvalidKey if its X does not decode to a public key.
+func (s *KeySet) Key(kid string) (ed25519.PublicKey, error) {
+	for _, jwk := range s.Keys {
+		if jwk.KeyID != kid || jwk.KeyType != keyTypeOKP || jwk.Curve != curveEd25519 ||
+			(jwk.Algorithm != "" && jwk.Algorithm != Algorithm) || (jwk.Use != "" && jwk.Use != keyUseSignature) {
+			continue
+		}
+		key, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// KeyID returns the JWK thumbprint (RFC 7638) of an Ed25519 public key: the base64url-encoded SHA-256 hash
+// of its required members in lexicographic order, so that the same key always gets the same ID.
+func KeyID(key ed25519.PublicKey) string {
+	thumbprint := fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, curveEd25519, keyTypeOKP,
+		base64.RawURLEncoding.EncodeToString(key))
+	sum := sha256.Sum256([]byte(thumbprint))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
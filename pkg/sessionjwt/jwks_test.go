@@ -0,0 +1,73 @@
+package sessionjwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyID(t *testing.T) {
+	t.Parallel()
+	// The thumbprint of the example key of RFC 8037, appendix A.3.
+	key, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	require.NoError(t, err)
+	require.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", KeyID(key))
+}
+
+func TestKeySet(t *testing.T) {
+	public, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	other, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	set, err := NewKeySet(public, other)
+	require.NoError(t, err)
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	parsed, err := ParseKeySet(data)
+	require.NoError(t, err)
+	require.Equal(t, set, parsed)
+
+	wrongCurve := *set
+	wrongCurve.Keys = []JWK{set.Keys[0]}
+	wrongCurve.Keys[0].Curve = "X25519"
+	badX := *set
+	badX.Keys = []JWK{set.Keys[0]}
+	badX.Keys[0].X = "AAAA"
+
+	tests := []struct {
+		name    string
+		set     *KeySet
+		kid     string
+		want    ed25519.PublicKey
+		wantErr error
+	}{
+		{name: "First key", set: parsed, kid: KeyID(public), want: public},
+		{name: "Second key", set: parsed, kid: KeyID(other), want: other},
+		{name: "Unknown key ID", set: parsed, kid: "unknown", wantErr: ErrUnknownKey},
+		{name: "Empty set", set: &KeySet{}, kid: KeyID(public), wantErr: ErrUnknownKey},
+		{name: "Other curve", set: &wrongCurve, kid: KeyID(public), wantErr: ErrUnknownKey},
+		{name: "Truncated key", set: &badX, kid: KeyID(public), wantErr: ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.set.Key(tt.kid)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err = NewKeySet(public[:16])
+	require.ErrorIs(t, err, ErrInvalidKey)
+	_, err = ParseKeySet([]byte("not json"))
+	require.Error(t, err)
+}
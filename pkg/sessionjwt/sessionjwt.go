@@ -0,0 +1,150 @@
+// Package sessionjwt issues and verifies the signed session tokens of the verifier. They are JSON Web
+// Tokens (RFC 7519) signed with Ed25519 (RFC 8037) that name the user, the session and when the token was
+// issued and expires, so that other services can check a login without calling the verifier: they fetch
+// the public keys once with the GetPublicKeys call, parse them with ParseKeySet and pass them to Verify.
+//
+// A signed token stays valid until it expires even if its session is revoked earlier, so the verifier
+// issues them with the expiry the session had when it was opened.
+package sessionjwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Algorithm is the JWS algorithm of signed tokens, EdDSA with Ed25519 keys. tokenType is their type.
+const (
+	Algorithm = "EdDSA"
+	tokenType = "JWT"
+)
+
+// tokenParts is the number of dot-separated parts of a signed token: header, claims and signature.
+const tokenParts = 3
+
+var (
+	// ErrInvalidKey is returned for a key that is not an Ed25519 key.
+	ErrInvalidKey = errors.New("invalid Ed25519 key")
+	// ErrMalformedToken is returned by Verify for a token that is not a well-formed signed token.
+	ErrMalformedToken = errors.New("malformed token")
+	// ErrUnsupportedAlgorithm is returned by Verify for a token that is not signed with Algorithm.
+	ErrUnsupportedAlgorithm = errors.New("unsupported token algorithm")
+	// ErrUnknownKey is returned by Verify for a token signed with a key that is not in the key set.
+	ErrUnknownKey = errors.New("unknown token key")
+	// ErrInvalidSignature is returned by Verify for a token whose signature does not match.
+	ErrInvalidSignature = errors.New("invalid token signature")
+	// ErrTokenExpired is returned by Verify for a token past its expiry.
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// Claims are the claims of a signed token. Subject is the user, SessionID the ID of the session as listed
+// by the ListSessions call of the verifier, and IssuedAt and ExpiresAt are Unix times.
+type Claims struct {
+	Subject   string `json:"sub"`
+	SessionID string `json:"sid"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// header is the JOSE header of a signed token.
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// Sign returns the claims as a token signed with key, whose header names the key by its KeyID.
+// It returns ErrInvalidKey if key is not an Ed25519 private key.
+func Sign(key ed25519.PrivateKey, claims Claims) (string, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return "", ErrInvalidKey
+	}
+	public, ok := key.Public().(ed25519.PublicKey)
+	if !ok {
+		return "", ErrInvalidKey
+	}
+
+	encodedHeader, err := encodeSegment(header{Algorithm: Algorithm, Type: tokenType, KeyID: KeyID(public)})
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodedHeader + "." + encodedClaims
+	signature := ed25519.Sign(key, []byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks that token is signed with Algorithm by a key of keys and has not expired at now, and
+// returns its claims.
+// It returns ErrMalformedToken, ErrUnsupportedAlgorithm, ErrUnknownKey, ErrInvalidKey, ErrInvalidSignature
+// or ErrTokenExpired accordingly.
+func Verify(token string, keys *KeySet, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != tokenParts {
+		return nil, ErrMalformedToken
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+	if h.Algorithm != Algorithm {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	if keys == nil {
+		return nil, ErrUnknownKey
+	}
+	key, err := keys.Key(h.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !ed25519.Verify(key, []byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims Claims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" || claims.SessionID == "" {
+		return nil, ErrMalformedToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	return &claims, nil
+}
+
+// encodeSegment returns v as base64url-encoded JSON.
+func encodeSegment(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeSegment decodes base64url-encoded JSON into v. It returns ErrMalformedToken if the segment
+// cannot be decoded.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformedToken
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return ErrMalformedToken
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package sessionjwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherPublic, otherPrivate, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	keys, err := NewKeySet(public, otherPublic)
+	require.NoError(t, err)
+	onlyOther, err := NewKeySet(otherPublic)
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	claims := Claims{Subject: "alice", SessionID: "abcd", IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Hour).Unix()}
+	token, err := Sign(private, claims)
+	require.NoError(t, err)
+	otherToken, err := Sign(otherPrivate, claims)
+	require.NoError(t, err)
+	expired, err := Sign(private, Claims{Subject: "alice", SessionID: "abcd", IssuedAt: now.Add(-time.Hour).Unix(),
+		ExpiresAt: now.Unix()})
+	require.NoError(t, err)
+	anonymous, err := Sign(private, Claims{SessionID: "abcd", ExpiresAt: now.Add(time.Hour).Unix()})
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"mallory","sid":"abcd","exp":9999999999}`)) +
+		"." + parts[2]
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+
+	tests := []struct {
+		name    string
+		token   string
+		keys    *KeySet
+		wantErr error
+	}{
+		{name: "Valid token", token: token, keys: keys},
+		{name: "Token of the second key", token: otherToken, keys: keys},
+		{name: "Key not in the set", token: token, keys: onlyOther, wantErr: ErrUnknownKey},
+		{name: "No key set", token: token, wantErr: ErrUnknownKey},
+		{name: "Expired", token: expired, keys: keys, wantErr: ErrTokenExpired},
+		{name: "Without subject", token: anonymous, keys: keys, wantErr: ErrMalformedToken},
+		{name: "Forged claims", token: forged, keys: keys, wantErr: ErrInvalidSignature},
+		{name: "Signature of another token", token: parts[0] + "." + parts[1] + "." + strings.Split(expired, ".")[2],
+			keys: keys, wantErr: ErrInvalidSignature},
+		{name: "Algorithm none", token: unsigned, keys: keys, wantErr: ErrUnsupportedAlgorithm},
+		{name: "Two parts", token: parts[0] + "." + parts[1], keys: keys, wantErr: ErrMalformedToken},
+		{name: "Header not base64url", token: "!." + parts[1] + "." + parts[2], keys: keys, wantErr: ErrMalformedToken},
+		{name: "Empty", keys: keys, wantErr: ErrMalformedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Verify(tt.token, tt.keys, now)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, claims, *got)
+		})
+	}
+
+	_, err = Sign(private[:ed25519.SeedSize], claims)
+	require.ErrorIs(t, err, ErrInvalidKey)
+}